@@ -0,0 +1,56 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AlarmAction represents an acknowledgement command sent to the alarm manager
+type AlarmAction struct {
+	Cmd string `json:"cmd"`           // Command to perform (archive-alarm, archive-all-alarms)
+	ID  string `json:"_id,omitempty"` // Alarm ID, required for archive-alarm
+}
+
+// AcknowledgeAlarm acknowledges (archives) a single alarm
+func (c *Client) AcknowledgeAlarm(ctx context.Context, siteID, alarmID string) error {
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+	if alarmID == "" {
+		return fmt.Errorf("alarmId is required")
+	}
+
+	action := &AlarmAction{
+		Cmd: "archive-alarm",
+		ID:  alarmID,
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/alarms", url.PathEscape(siteID))
+	err := c.do(ctx, http.MethodPost, urlPath, action, nil)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge alarm: %w", err)
+	}
+
+	return nil
+}
+
+// AcknowledgeAllAlarms acknowledges (archives) every active alarm for a site
+func (c *Client) AcknowledgeAllAlarms(ctx context.Context, siteID string) error {
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+
+	action := &AlarmAction{
+		Cmd: "archive-all-alarms",
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/alarms", url.PathEscape(siteID))
+	err := c.do(ctx, http.MethodPost, urlPath, action, nil)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge all alarms: %w", err)
+	}
+
+	return nil
+}
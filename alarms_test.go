@@ -0,0 +1,88 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_AcknowledgeAlarm(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, nil)
+
+		if err := client.AcknowledgeAlarm(ctx, testSiteID, "alarm1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if mock.request.Method != http.MethodPost {
+			t.Errorf("expected method %s, got %s", http.MethodPost, mock.request.Method)
+		}
+		var action AlarmAction
+		decodeRequestBody(t, mock.request, &action)
+		if action.Cmd != "archive-alarm" {
+			t.Errorf("expected cmd %q, got %q", "archive-alarm", action.Cmd)
+		}
+		if action.ID != "alarm1" {
+			t.Errorf("expected alarm ID %q, got %q", "alarm1", action.ID)
+		}
+	})
+
+	t.Run("missing alarm ID", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if err := client.AcknowledgeAlarm(ctx, testSiteID, ""); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("alarm not found", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(404, Error{
+			Status:     404,
+			StatusName: "Not Found",
+			Message:    "Alarm not found",
+		})
+
+		err := client.AcknowledgeAlarm(ctx, testSiteID, "nonexistent")
+		assertErrorResponse(t, err, 404, "Alarm not found")
+	})
+}
+
+func TestClient_AcknowledgeAllAlarms(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, nil)
+
+		if err := client.AcknowledgeAllAlarms(ctx, testSiteID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var action AlarmAction
+		decodeRequestBody(t, mock.request, &action)
+		if action.Cmd != "archive-all-alarms" {
+			t.Errorf("expected cmd %q, got %q", "archive-all-alarms", action.Cmd)
+		}
+		if action.ID != "" {
+			t.Errorf("expected no alarm ID, got %q", action.ID)
+		}
+	})
+
+	t.Run("site not found", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(404, Error{
+			Status:     404,
+			StatusName: "Not Found",
+			Message:    "Site not found",
+		})
+
+		err := client.AcknowledgeAllAlarms(ctx, "nonexistent")
+		assertErrorResponse(t, err, 404, "Site not found")
+	})
+}
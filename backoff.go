@@ -0,0 +1,69 @@
+package unifi
+
+import (
+	"math"
+	"time"
+)
+
+// BackoffPolicy decides how long to wait before a retry. NextDelay receives
+// the 0-based retry attempt and returns the delay before that attempt.
+type BackoffPolicy interface {
+	NextDelay(attempt int) time.Duration
+}
+
+// WithBackoff sets the policy used to space out retries enabled with
+// WithMaxRetries. The default is ExponentialBackoff starting at 500ms and
+// capped at 30s.
+func WithBackoff(policy BackoffPolicy) ClientOption {
+	return func(c *Client) {
+		c.backoff = policy
+	}
+}
+
+// ConstantBackoff waits the same Delay before every retry
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+// NextDelay implements BackoffPolicy
+func (b ConstantBackoff) NextDelay(int) time.Duration {
+	return b.Delay
+}
+
+// ExponentialBackoff waits Base before the first retry and doubles the delay
+// on each further attempt. A positive Max caps the delay; without one the
+// delay saturates at the largest time.Duration instead of overflowing.
+type ExponentialBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// NextDelay implements BackoffPolicy
+func (b ExponentialBackoff) NextDelay(attempt int) time.Duration {
+	delay := b.Base
+	for i := 0; i < attempt; i++ {
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+		delay *= 2
+		if b.Max > 0 && delay >= b.Max {
+			return b.Max
+		}
+	}
+	if b.Max > 0 && delay > b.Max {
+		return b.Max
+	}
+	return delay
+}
+
+// NoBackoff retries immediately without waiting
+type NoBackoff struct{}
+
+// NextDelay implements BackoffPolicy
+func (NoBackoff) NextDelay(int) time.Duration {
+	return 0
+}
+
+// defaultBackoff is the policy used when WithBackoff is not given
+var defaultBackoff BackoffPolicy = ExponentialBackoff{Base: retryBaseDelay, Max: retryMaxDelay}
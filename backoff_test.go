@@ -0,0 +1,120 @@
+package unifi
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBackoffPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy BackoffPolicy
+		want   []time.Duration
+	}{
+		{
+			name:   "constant",
+			policy: ConstantBackoff{Delay: 2 * time.Second},
+			want:   []time.Duration{2 * time.Second, 2 * time.Second, 2 * time.Second, 2 * time.Second},
+		},
+		{
+			name:   "exponential",
+			policy: ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second},
+			want: []time.Duration{
+				100 * time.Millisecond,
+				200 * time.Millisecond,
+				400 * time.Millisecond,
+				800 * time.Millisecond,
+				time.Second,
+				time.Second,
+			},
+		},
+		{
+			name:   "exponential without cap",
+			policy: ExponentialBackoff{Base: time.Second},
+			want:   []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second},
+		},
+		{
+			name:   "exponential base above cap",
+			policy: ExponentialBackoff{Base: time.Minute, Max: time.Second},
+			want:   []time.Duration{time.Second, time.Second},
+		},
+		{
+			name:   "none",
+			policy: NoBackoff{},
+			want:   []time.Duration{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for attempt, want := range tt.want {
+				if got := tt.policy.NextDelay(attempt); got != want {
+					t.Errorf("NextDelay(%d) = %v, want %v", attempt, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExponentialBackoffLargeAttempt(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy ExponentialBackoff
+		want   time.Duration
+	}{
+		{name: "uncapped saturates", policy: ExponentialBackoff{Base: time.Second}, want: math.MaxInt64},
+		{name: "capped", policy: ExponentialBackoff{Base: time.Second, Max: time.Minute}, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, attempt := range []int{40, 63, 64, 100, 10000} {
+				if got := tt.policy.NextDelay(attempt); got != tt.want {
+					t.Errorf("NextDelay(%d) = %v, want %v", attempt, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithBackoff(t *testing.T) {
+	t.Run("nil policy is rejected", func(t *testing.T) {
+		_, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithBackoff(nil))
+		if err == nil {
+			t.Fatal("expected error for nil backoff policy, got nil")
+		}
+	})
+
+	t.Run("retries are spaced by the policy", func(t *testing.T) {
+		client, mock, clock := newRetryTestClient(t, 3)
+		client.backoff = ConstantBackoff{Delay: 250 * time.Millisecond}
+		mock.response = mockRawResponse(503, "<html>maintenance</html>")
+
+		_, err := client.GetApplicationInfo(context.Background())
+		if !IsUnavailable(err) {
+			t.Errorf("expected unavailable error, got %v", err)
+		}
+
+		want := []time.Duration{250 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond}
+		if got := clock.Sleeps(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected sleeps %v, got %v", want, got)
+		}
+	})
+
+	t.Run("no backoff retries without sleeping", func(t *testing.T) {
+		client, mock, clock := newRetryTestClient(t, 2)
+		client.backoff = NoBackoff{}
+		mock.response = mockRawResponse(503, "<html>maintenance</html>")
+
+		_, _ = client.GetApplicationInfo(context.Background())
+		if len(mock.requests) != 3 {
+			t.Errorf("expected 3 attempts, got %d", len(mock.requests))
+		}
+		if sleeps := clock.Sleeps(); len(sleeps) != 0 {
+			t.Errorf("expected no sleeps, got %v", sleeps)
+		}
+	})
+}
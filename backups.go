@@ -0,0 +1,58 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Backup represents a controller configuration backup
+type Backup struct {
+	ID       string `json:"id"`       // Unique identifier
+	Filename string `json:"filename"` // Backup file name (e.g., autobackup_9.1.0_20240101_0000_1704067200000.unf)
+	Size     int64  `json:"size"`     // File size in bytes
+	Time     int64  `json:"time"`     // Creation time in milliseconds since epoch
+	Version  string `json:"version"`  // Network application version that created the backup
+	Type     string `json:"type"`     // How the backup was created (manual, scheduled)
+}
+
+// Created returns the backup creation time as a time.Time
+func (b Backup) Created() time.Time {
+	return time.UnixMilli(b.Time)
+}
+
+// ListBackups retrieves the configuration backups stored on the controller
+func (c *Client) ListBackups(ctx context.Context) ([]Backup, error) {
+	var response struct {
+		Data []Backup `json:"data"`
+	}
+
+	if err := c.do(ctx, http.MethodGet, "/v1/backups", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	if response.Data == nil {
+		return []Backup{}, nil
+	}
+
+	return response.Data, nil
+}
+
+// DownloadBackup streams a configuration backup file. Backups can be large,
+// so the body is not buffered; the caller must close the returned reader.
+func (c *Client) DownloadBackup(ctx context.Context, backupID string) (io.ReadCloser, error) {
+	if backupID == "" {
+		return nil, fmt.Errorf("backupId is required")
+	}
+
+	urlPath := fmt.Sprintf("/v1/backups/%s/download", url.PathEscape(backupID))
+	body, err := c.stream(ctx, urlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download backup: %w", err)
+	}
+
+	return body, nil
+}
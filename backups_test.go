@@ -0,0 +1,123 @@
+package unifi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_ListBackups(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":[
+			{"id":"b1","filename":"autobackup_9.1.0_20240101.unf","size":1048576,"time":1704067200000,"version":"9.1.0","type":"scheduled"},
+			{"id":"b2","filename":"manual.unf","size":2048,"time":1704153600000,"version":"9.1.0","type":"manual"}
+		]}`)
+
+		backups, err := client.ListBackups(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := mock.request.URL.Path; !strings.HasSuffix(got, "/v1/backups") {
+			t.Errorf("unexpected request path: %s", got)
+		}
+		if len(backups) != 2 {
+			t.Fatalf("expected 2 backups, got %d", len(backups))
+		}
+		if backups[0].ID != "b1" || backups[0].Size != 1048576 || backups[0].Type != "scheduled" {
+			t.Errorf("unexpected first backup: %+v", backups[0])
+		}
+		if want := time.UnixMilli(1704067200000); !backups[0].Created().Equal(want) {
+			t.Errorf("expected created %v, got %v", want, backups[0].Created())
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":null}`)
+
+		backups, err := client.ListBackups(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if backups == nil || len(backups) != 0 {
+			t.Errorf("expected empty non-nil slice, got %#v", backups)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(403, Error{Status: 403, StatusName: "Forbidden", Message: "Forbidden"})
+
+		_, err := client.ListBackups(ctx)
+		assertErrorResponse(t, err, 403, "Forbidden")
+	})
+}
+
+func TestClient_DownloadBackup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stream is passed through", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		content := strings.Repeat("backup-bytes", 10000)
+		body := &trackingBody{r: strings.NewReader(content)}
+		mock.response = &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       body,
+			Header:     http.Header{"Content-Type": {"application/octet-stream"}},
+		}
+
+		rc, err := client.DownloadBackup(ctx, "b 1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if body.read != 0 {
+			t.Errorf("expected body to be unread before the caller reads it, %d bytes already read", body.read)
+		}
+		if got := mock.request.URL.EscapedPath(); !strings.HasSuffix(got, "/v1/backups/b%201/download") {
+			t.Errorf("unexpected request path: %s", got)
+		}
+
+		got, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		if string(got) != content {
+			t.Errorf("expected %d bytes of content, got %d", len(content), len(got))
+		}
+
+		if err := rc.Close(); err != nil {
+			t.Fatalf("unexpected close error: %v", err)
+		}
+		if !body.closed {
+			t.Error("expected closing the stream to close the response body")
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(404, Error{Status: 404, StatusName: "Not Found", Message: "Backup not found"})
+
+		rc, err := client.DownloadBackup(ctx, "missing")
+		if rc != nil {
+			t.Error("expected no stream on error")
+		}
+		assertErrorResponse(t, err, 404, "Backup not found")
+	})
+
+	t.Run("missing backup ID", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if _, err := client.DownloadBackup(ctx, ""); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
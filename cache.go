@@ -0,0 +1,139 @@
+package unifi
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithListCache caches ListDevices responses in memory for ttl, keyed by site
+// and query parameters, so dashboards that refresh frequently do not hit the
+// controller on every call. Any mutating request (POST, PUT, PATCH, DELETE)
+// for a site drops that site's cached entries. Caching is disabled by default.
+func WithListCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.listCacheTTL = ttl
+	}
+}
+
+// listCache is a TTL cache of list responses keyed by request path. A nil
+// *listCache is valid and never caches.
+//
+// Each entry records the API key it was fetched with and is only returned for
+// that key, so a shared cache never serves a response fetched with a key the
+// caller no longer holds.
+//
+// Each site has a generation that invalidate bumps. Callers read it with
+// generation before fetching and pass it to set, so a response fetched
+// before a mutation finished is not cached after it.
+type listCache struct {
+	ttl         time.Duration
+	mu          sync.Mutex
+	entries     map[string]listCacheEntry
+	generations map[string]uint64 // Keyed by sitePathPrefix
+}
+
+type listCacheEntry struct {
+	value   interface{}
+	apiKey  string
+	expires time.Time
+}
+
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{
+		ttl:         ttl,
+		entries:     make(map[string]listCacheEntry),
+		generations: make(map[string]uint64),
+	}
+}
+
+// get returns the cached value for urlPath if it was fetched with apiKey and
+// has not expired as of now
+func (lc *listCache) get(urlPath, apiKey string, now time.Time) (interface{}, bool) {
+	if lc == nil {
+		return nil, false
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	entry, ok := lc.entries[urlPath]
+	if !ok || entry.apiKey != apiKey {
+		return nil, false
+	}
+	if !now.Before(entry.expires) {
+		delete(lc.entries, urlPath)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// generation returns the current generation of the site urlPath addresses
+func (lc *listCache) generation(urlPath string) uint64 {
+	if lc == nil {
+		return 0
+	}
+	prefix, _ := sitePathPrefix(urlPath)
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.generations[prefix]
+}
+
+// set caches value, fetched with apiKey, for urlPath until ttl after now,
+// unless the site has been invalidated since gen was read with generation
+func (lc *listCache) set(urlPath, apiKey string, value interface{}, now time.Time, gen uint64) {
+	if lc == nil {
+		return
+	}
+	prefix, _ := sitePathPrefix(urlPath)
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if lc.generations[prefix] != gen {
+		return
+	}
+	lc.entries[urlPath] = listCacheEntry{value: value, apiKey: apiKey, expires: now.Add(lc.ttl)}
+}
+
+// invalidate drops every entry belonging to the site that urlPath addresses
+func (lc *listCache) invalidate(urlPath string) {
+	if lc == nil {
+		return
+	}
+	prefix, ok := sitePathPrefix(urlPath)
+	if !ok {
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.generations[prefix]++
+	for key := range lc.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(lc.entries, key)
+		}
+	}
+}
+
+// clear drops every entry
+func (lc *listCache) clear() {
+	if lc == nil {
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	clear(lc.entries)
+}
+
+// sitePathPrefix returns the "/v1/sites/{siteId}/" prefix of an API path
+func sitePathPrefix(urlPath string) (string, bool) {
+	p, _, _ := strings.Cut(urlPath, "?")
+	rest, ok := strings.CutPrefix(p, "/v1/sites/")
+	if !ok {
+		return "", false
+	}
+	site, _, _ := strings.Cut(rest, "/")
+	if site == "" {
+		return "", false
+	}
+	return "/v1/sites/" + site + "/", true
+}
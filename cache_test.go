@@ -0,0 +1,256 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCachingTestClient creates a test client with a list cache on a fake clock
+func newCachingTestClient(t *testing.T, ttl time.Duration) (*Client, *mockTransport, *fakeClock) {
+	t.Helper()
+	client, mock := newTestClient(t, testBaseURL)
+	clock := newFakeClock()
+	client.clock = clock
+	client.listCache = newListCache(ttl)
+	return client, mock, clock
+}
+
+func devicesResponse(ids ...string) *http.Response {
+	devices := make([]Device, len(ids))
+	for i, id := range ids {
+		devices[i] = Device{ID: id}
+	}
+	return mockResponse(200, ListDevicesResponse{
+		PaginatedResponse: PaginatedResponse{Count: len(ids), TotalCount: len(ids)},
+		Data:              devices,
+	})
+}
+
+func TestWithListCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("negative TTL is rejected", func(t *testing.T) {
+		_, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithListCache(-time.Second))
+		if err == nil {
+			t.Fatal("expected error for negative TTL, got nil")
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{devicesResponse("ap1"), devicesResponse("ap1")}
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if len(mock.requests) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("hit within TTL avoids a request", func(t *testing.T) {
+		client, mock, clock := newCachingTestClient(t, time.Minute)
+		mock.responses = []*http.Response{devicesResponse("ap1")}
+
+		first, err := client.ListDevices(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		first.Data[0].ID = "mutated"
+
+		clock.Advance(59 * time.Second)
+		second, err := client.ListDevices(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mock.requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.requests))
+		}
+		if second.Data[0].ID != "ap1" {
+			t.Errorf("expected cached device ap1, got %s", second.Data[0].ID)
+		}
+	})
+
+	t.Run("expiry triggers a refetch", func(t *testing.T) {
+		client, mock, clock := newCachingTestClient(t, time.Minute)
+		mock.responses = []*http.Response{devicesResponse("ap1"), devicesResponse("ap2")}
+
+		if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		clock.Advance(time.Minute)
+		resp, err := client.ListDevices(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mock.requests) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(mock.requests))
+		}
+		if resp.Data[0].ID != "ap2" {
+			t.Errorf("expected refetched device ap2, got %s", resp.Data[0].ID)
+		}
+	})
+
+	t.Run("params are cached separately", func(t *testing.T) {
+		client, mock, _ := newCachingTestClient(t, time.Minute)
+		mock.responses = []*http.Response{devicesResponse("ap1"), devicesResponse("ap2")}
+
+		if _, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Limit: 10}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Limit: 20}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Limit: 10}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mock.requests) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("entries are only served for the key that fetched them", func(t *testing.T) {
+		client, mock, _ := newCachingTestClient(t, time.Minute)
+		mock.responses = []*http.Response{devicesResponse("ap1"), devicesResponse("ap2"), devicesResponse("ap3")}
+
+		if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		clone, err := client.Clone()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := clone.SetAPIKey("other-key"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := clone.ListDevices(ctx, testSiteID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, err := client.ListDevices(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mock.requests) != 3 || resp.Data[0].ID != "ap3" {
+			t.Errorf("expected the list fetched with the clone's key not to be served, got %d requests and %+v", len(mock.requests), resp.Data)
+		}
+	})
+
+	t.Run("mutation invalidates the same site only", func(t *testing.T) {
+		client, mock, _ := newCachingTestClient(t, time.Minute)
+		mock.responses = []*http.Response{
+			devicesResponse("ap1"),                      // default site
+			devicesResponse("other-ap"),                 // other site
+			mockResponse(200, map[string]interface{}{}), // device action on default site
+			devicesResponse("ap1"),                      // default site refetch
+		}
+
+		if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.ListDevices(ctx, "other", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := client.ExecuteDeviceAction(ctx, testSiteID, "ap1", &DeviceAction{Action: "restart"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.ListDevices(ctx, "other", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mock.requests) != 4 {
+			t.Errorf("expected 4 requests, got %d", len(mock.requests))
+		}
+	})
+}
+
+// gatedTransport holds the first GET until release is closed, so a test can
+// run a mutation while that GET is in flight. Later GETs return fresh data.
+type gatedTransport struct {
+	started chan struct{}
+	release chan struct{}
+	gets    atomic.Int32
+}
+
+func (t *gatedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return mockResponse(200, map[string]interface{}{}), nil
+	}
+	if t.gets.Add(1) == 1 {
+		close(t.started)
+		<-t.release
+		return devicesResponse("stale"), nil
+	}
+	return devicesResponse("fresh"), nil
+}
+
+func TestListCache_SlowGetDuringMutation(t *testing.T) {
+	ctx := context.Background()
+	transport := &gatedTransport{started: make(chan struct{}), release: make(chan struct{})}
+	client, err := NewClient(testBaseURL,
+		WithAPIKey("test-api-key"),
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithListCache(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := client.ListDevices(ctx, testSiteID, nil)
+		done <- err
+	}()
+
+	<-transport.started
+	if err := client.SetDeviceMgmtVLAN(ctx, testSiteID, "sw1", 20); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(transport.release)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := client.ListDevices(ctx, testSiteID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID != "fresh" {
+		t.Errorf("expected the list fetched before the mutation not to be cached, got %+v", resp.Data)
+	}
+	if got := transport.gets.Load(); got != 2 {
+		t.Errorf("expected 2 GET requests, got %d", got)
+	}
+}
+
+func TestSitePathPrefix(t *testing.T) {
+	tests := []struct {
+		urlPath string
+		want    string
+		ok      bool
+	}{
+		{urlPath: "/v1/sites/default/devices?limit=10", want: "/v1/sites/default/", ok: true},
+		{urlPath: "/v1/sites/a%20b/devices/ap1", want: "/v1/sites/a%20b/", ok: true},
+		{urlPath: "/v1/sites?limit=10", ok: false},
+		{urlPath: "/v1/info", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := sitePathPrefix(tt.urlPath)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("sitePathPrefix(%q) = %q, %v; want %q, %v", tt.urlPath, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -3,8 +3,8 @@ package unifi
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -13,6 +13,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 )
 
 type Error struct {
@@ -22,17 +23,42 @@ type Error struct {
 	Timestamp   string `json:"timestamp"`
 	RequestPath string `json:"requestPath"`
 	RequestID   string `json:"requestId"`
+
+	// RawBody is the response body exactly as the controller sent it, for
+	// debugging errors the fields above do not explain
+	RawBody []byte `json:"-"`
+
+	err error // Underlying sentinel error, if any
 }
 
-// Client represents a UniFi Network API client
+// Client represents a UniFi Network API client. It is safe for concurrent
+// use; see clientState for how its mutable state is guarded.
 type Client struct {
-	baseURL    *url.URL
-	httpClient *http.Client
-	apiKey     string
-	insecure   bool
-	logger     *slog.Logger
+	baseURL       *url.URL
+	httpClient    *http.Client
+	insecure      bool
+	logger        *slog.Logger
+	concurrency   int
+	clock         Clock
+	maxRetries    int
+	retryBudget   time.Duration
+	backoff       BackoffPolicy
+	headers       http.Header
+	trailingSlash bool
+	rawBaseURL    bool
+	listCacheTTL  time.Duration
+	listCache     *listCache // Cached list responses, nil when caching is disabled
+	maxInFlight   int
+	inFlight      chan struct{} // Semaphore bounding in-flight requests, nil when unlimited
+	reauthOn401   bool
+	maxPageLimit  int
+	deviceModels  *deviceModelCache // Shared with clones that keep the same credentials
+	state         *clientState      // API key and other state that changes at runtime
 }
 
+// defaultConcurrency is the default worker pool size for fan-out helpers
+const defaultConcurrency = 5
+
 // ClientOption allows for customizing the client
 type ClientOption func(*Client)
 
@@ -46,7 +72,7 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 // WithAPIKey sets the API key for authentication
 func WithAPIKey(apiKey string) ClientOption {
 	return func(c *Client) {
-		c.apiKey = apiKey
+		c.state.apiKey = apiKey
 	}
 }
 
@@ -64,18 +90,73 @@ func WithLogger(logger *slog.Logger) ClientOption {
 	}
 }
 
+// WithHeader adds a header sent with every request, for example a header
+// required by a proxy in front of the controller. It may be given more than
+// once; repeating a key replaces its value. Headers the client manages itself
+// (X-API-KEY, Content-Type, Accept, Idempotency-Key) cannot be set this way.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Set(key, value)
+	}
+}
+
+// WithTrailingSlash controls whether request paths end in a slash. The
+// integration API serves every endpoint without one, which is the default;
+// the client strips any trailing slash so paths are consistent. Some reverse
+// proxies and older controller builds instead answer 404 (or redirect, which
+// drops the request body) unless the path ends in a slash; enable this for
+// those deployments. The setting applies to every endpoint, including list
+// endpoints with a query string, where the slash goes before the '?'.
+func WithTrailingSlash(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.trailingSlash = enabled
+	}
+}
+
+// WithRawBaseURL uses the base URL passed to NewClient exactly as given,
+// without adding the /proxy/network/integration prefix. Use it behind a
+// reverse proxy that already maps a path of its own onto the integration
+// API; endpoint paths such as /v1/sites are appended directly to the URL.
+// It cannot be changed with Clone.
+func WithRawBaseURL() ClientOption {
+	return func(c *Client) {
+		c.rawBaseURL = true
+	}
+}
+
+// protectedHeaders are set by the client on every request and cannot be overridden
+var protectedHeaders = []string{"X-API-KEY", "Content-Type", "Accept", idempotencyKeyHeader}
+
+// WithConcurrency sets the maximum number of simultaneous requests issued by
+// fan-out helpers such as GetDevices. It must be at least 1.
+func WithConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.concurrency = n
+	}
+}
+
 // NewClient creates a new UniFi Network API client
 func NewClient(baseURL string, options ...ClientOption) (*Client, error) {
+	// Without "://" a host:port such as unifi.local:8443 parses as a scheme
+	// and 192.168.1.1:8443 fails to parse at all, so check before parsing
+	if !strings.Contains(baseURL, "://") {
+		return nil, fmt.Errorf("invalid base URL %q: missing scheme, expected https:// or http://", baseURL)
+	}
+
 	parsedURL, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid base URL: %w", err)
 	}
 
-	// Ensure the base path includes the API prefix
-	// First, trim any existing proxy/network/integration prefix to avoid doubles
-	trimmedPath := strings.TrimPrefix(parsedURL.Path, "/proxy/network/integration")
-	trimmedPath = strings.TrimPrefix(trimmedPath, "proxy/network/integration")
-	parsedURL.Path = path.Join("/proxy/network/integration", trimmedPath)
+	switch {
+	case parsedURL.Scheme != "https" && parsedURL.Scheme != "http":
+		return nil, fmt.Errorf("invalid base URL %q: unsupported scheme %q, expected https or http", baseURL, parsedURL.Scheme)
+	case parsedURL.Host == "":
+		return nil, fmt.Errorf("invalid base URL %q: missing host", baseURL)
+	}
 
 	// Create default logger
 	logLevel := new(slog.LevelVar)
@@ -89,27 +170,41 @@ func NewClient(baseURL string, options ...ClientOption) (*Client, error) {
 	}))
 
 	client := &Client{
-		baseURL:    parsedURL,
-		httpClient: http.DefaultClient,
-		logger:     defaultLogger,
+		baseURL:      parsedURL,
+		logger:       defaultLogger,
+		concurrency:  defaultConcurrency,
+		clock:        realClock{},
+		backoff:      defaultBackoff,
+		maxPageLimit: MaxPageLimit,
+		deviceModels: newDeviceModelCache(),
+		state:        &clientState{},
 	}
 
 	for _, opt := range options {
 		opt(client)
 	}
 
-	if client.apiKey == "" {
-		return nil, fmt.Errorf("API key is required")
+	if err := client.validate(); err != nil {
+		return nil, err
 	}
 
-	// Configure TLS if insecure is set
-	if client.insecure {
-		transport := http.DefaultTransport.(*http.Transport).Clone()
-		transport.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
-		}
+	// Ensure the base path includes the API prefix
+	if !client.rawBaseURL {
+		setAPIBasePath(client.baseURL)
+	}
+
+	if client.listCacheTTL > 0 {
+		client.listCache = newListCache(client.listCacheTTL)
+	}
+	if client.maxInFlight > 0 {
+		client.inFlight = make(chan struct{}, client.maxInFlight)
+	}
+
+	// Use a dedicated transport unless the caller supplied a client; insecure
+	// needs its own TLS config, so it always gets one
+	if client.httpClient == nil || client.insecure {
 		client.httpClient = &http.Client{
-			Transport: transport,
+			Transport: newDefaultTransport(client.insecure),
 		}
 	}
 
@@ -120,6 +215,87 @@ func NewClient(baseURL string, options ...ClientOption) (*Client, error) {
 	return client, nil
 }
 
+// validate checks the settings applied by client options
+func (c *Client) validate() error {
+	if c.state.apiKey == "" {
+		return fmt.Errorf("API key is required")
+	}
+
+	if c.clock == nil {
+		return fmt.Errorf("clock cannot be nil")
+	}
+
+	if c.maxRetries < 0 {
+		return fmt.Errorf("max retries cannot be negative")
+	}
+
+	if c.retryBudget < 0 {
+		return fmt.Errorf("retry budget cannot be negative")
+	}
+
+	if c.backoff == nil {
+		return fmt.Errorf("backoff policy cannot be nil")
+	}
+
+	if c.concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	if c.listCacheTTL < 0 {
+		return fmt.Errorf("list cache TTL cannot be negative")
+	}
+
+	if c.maxInFlight < 0 {
+		return fmt.Errorf("max concurrent requests cannot be negative")
+	}
+
+	if c.maxPageLimit < 1 {
+		return fmt.Errorf("max page limit must be at least 1")
+	}
+
+	for _, key := range protectedHeaders {
+		if _, ok := c.headers[http.CanonicalHeaderKey(key)]; ok {
+			return fmt.Errorf("header %s cannot be overridden", key)
+		}
+	}
+
+	return nil
+}
+
+// apiPrefix is the path under which the controller serves the integration API
+const apiPrefix = "/proxy/network/integration"
+
+// setAPIBasePath rewrites the URL path so it starts with apiPrefix exactly once.
+// It works on the escaped path so encoded characters survive, and it never
+// leaves empty segments or a trailing slash behind. Any existing path is kept
+// after the prefix.
+func setAPIBasePath(u *url.URL) {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(u.EscapedPath(), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	// Trim any existing proxy/network/integration prefix to avoid doubles
+	prefix := strings.Split(strings.TrimPrefix(apiPrefix, "/"), "/")
+	if len(segments) >= len(prefix) && strings.Join(segments[:len(prefix)], "/") == strings.Join(prefix, "/") {
+		segments = segments[len(prefix):]
+	}
+
+	escaped := apiPrefix
+	if len(segments) > 0 {
+		escaped += "/" + strings.Join(segments, "/")
+	}
+
+	// EscapedPath always returns a valid encoding, so unescaping cannot fail
+	u.Path, _ = url.PathUnescape(escaped)
+	u.RawPath = ""
+	if u.EscapedPath() != escaped {
+		u.RawPath = escaped
+	}
+}
+
 // PaginatedResponse represents a paginated API response
 type PaginatedResponse struct {
 	Offset     int             `json:"offset"`
@@ -127,6 +303,14 @@ type PaginatedResponse struct {
 	Count      int             `json:"count"`
 	TotalCount int             `json:"totalCount"`
 	Data       json.RawMessage `json:"data"`
+	NextCursor string          `json:"nextCursor,omitempty"` // Token for the next page on cursor-paginated controllers
+	Cursor     string          `json:"cursor,omitempty"`     // Alternative name some controllers use for NextCursor
+}
+
+// NextPageCursor returns the token for the next page when the controller
+// paginates by cursor, or "" when it uses offsets or this is the last page
+func (r PaginatedResponse) NextPageCursor() string {
+	return firstCursor(r.NextCursor, r.Cursor)
 }
 
 // ApplicationInfo represents the UniFi Network application information
@@ -145,12 +329,51 @@ func (c *Client) GetApplicationInfo(ctx context.Context) (*ApplicationInfo, erro
 	return &response, nil
 }
 
-func (c *Client) do(ctx context.Context, method, urlPath string, body interface{}, result interface{}) error {
+// getFirst fetches a single-item "data" envelope and decodes its first element
+// into T, returning the raw element as well. A nil result means the envelope was empty.
+func getFirst[T any](ctx context.Context, c *Client, urlPath string) (*T, json.RawMessage, error) {
+	var response struct {
+		Data []json.RawMessage `json:"data"`
+	}
+
+	if err := c.do(ctx, http.MethodGet, urlPath, nil, &response); err != nil {
+		return nil, nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, nil, nil
+	}
+
+	var result T
+	if err := json.Unmarshal(response.Data[0], &result); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, response.Data[0], nil
+}
+
+// requestURL resolves an API path, which may carry a query string, against
+// the base URL. The path is already escaped, so it is joined onto the
+// escaped base path to keep encoded segments intact.
+func (c *Client) requestURL(urlPath string) (*url.URL, error) {
 	u := *c.baseURL
 
-	// Split the path and query if present
-	pathParts := strings.Split(urlPath, "?")
-	u.Path = path.Join(u.Path, pathParts[0])
+	pathParts := strings.SplitN(urlPath, "?", 2)
+	// path.Join also strips any trailing slash, so paths are consistent
+	// regardless of how the caller or base URL spelled them
+	escaped := path.Join(c.baseURL.EscapedPath(), pathParts[0])
+	if c.trailingSlash {
+		escaped += "/"
+	}
+	unescaped, err := url.PathUnescape(escaped)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request path: %w", err)
+	}
+	u.Path = unescaped
+	u.RawPath = ""
+	if u.EscapedPath() != escaped {
+		u.RawPath = escaped
+	}
 
 	// Add query parameters if they exist
 	if len(pathParts) > 1 {
@@ -164,68 +387,285 @@ func (c *Client) do(ctx context.Context, method, urlPath string, body interface{
 		"query_params", u.RawQuery,
 		"final_url", u.String())
 
-	var bodyReader io.Reader
+	return &u, nil
+}
+
+func (c *Client) do(ctx context.Context, method, urlPath string, body interface{}, result interface{}) error {
+	u, err := c.requestURL(urlPath)
+	if err != nil {
+		return err
+	}
+
+	if method != http.MethodGet && method != http.MethodHead {
+		// Drop cached lists once the change has been applied (or failed)
+		defer c.listCache.invalidate(urlPath)
+	}
+
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 		c.logger.Debug("Request body", "body", string(jsonBody))
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	retryable := isRetryableMethod(ctx, method)
+	var waited time.Duration
+	reauthed := false
+	for attempt := 0; ; attempt++ {
+		resp, respBody, err := c.send(ctx, method, u.String(), jsonBody)
+		if c.reauthOn401 && !reauthed && err == nil && resp.StatusCode == http.StatusUnauthorized {
+			reauthed = true
+			attempt-- // The repeat does not count against maxRetries
+			c.logger.Debug("Repeating unauthorized request",
+				"method", method,
+				"url", u.String(),
+				"delay", reauthDelay)
+			if err := c.sleep(ctx, reauthDelay); err != nil {
+				return fmt.Errorf("failed to execute request: %w", err)
+			}
+			continue
+		}
+		if retryable && attempt < c.maxRetries && shouldRetry(ctx, resp, err) {
+			delay := c.backoff.NextDelay(attempt)
+			if resp != nil {
+				if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), c.clock.Now()); ok {
+					delay = min(wait, retryMaxDelay)
+				}
+			}
+			if c.retryBudget > 0 && waited+delay > c.retryBudget {
+				c.logger.Debug("Retry budget exhausted",
+					"method", method,
+					"url", u.String(),
+					"waited", waited,
+					"budget", c.retryBudget)
+				if err != nil {
+					return err
+				}
+				return c.handleResponse(u.Path, resp, respBody, result)
+			}
+			waited += delay
+			c.logger.Debug("Retrying request",
+				"method", method,
+				"url", u.String(),
+				"attempt", attempt+1,
+				"delay", delay)
+			if err := c.sleep(ctx, delay); err != nil {
+				return fmt.Errorf("failed to execute request: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		return c.handleResponse(u.Path, resp, respBody, result)
 	}
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("X-API-KEY", c.apiKey)
+// send performs a single HTTP round trip and reads the full response body.
+// A fresh body reader is created on every call so retried requests resend
+// the same payload.
+func (c *Client) send(ctx context.Context, method, rawURL string, jsonBody []byte) (*http.Response, []byte, error) {
+	var bodyReader io.Reader
+	if jsonBody != nil {
+		bodyReader = bytes.NewReader(jsonBody)
+	}
 
-	c.logger.Debug("Making request",
-		"method", method,
-		"url", u.String(),
-		"headers", req.Header)
+	req, err := c.newRequest(ctx, method, rawURL, bodyReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.acquire(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer c.release()
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return nil, nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
+	c.recordRateLimit(resp)
 
 	// Read the entire response body for debugging
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	c.logger.Debug("Received response",
 		"status", resp.Status,
 		"body_length", len(respBody))
 
+	return resp, respBody, nil
+}
+
+// newRequest creates an HTTP request carrying the client's headers
+func (c *Client) newRequest(ctx context.Context, method, rawURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Custom headers go first so the client's own headers always win
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-API-KEY", c.state.key())
+	if key, ok := idempotencyKeyFromContext(ctx); ok {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	c.logger.Debug("Making request",
+		"method", method,
+		"url", rawURL,
+		"headers", req.Header)
+
+	return req, nil
+}
+
+// stream performs a GET and returns the response body unread so large
+// downloads are not buffered in memory. Error responses are read and
+// decoded like any other request. Streams are never retried.
+func (c *Client) stream(ctx context.Context, urlPath string) (io.ReadCloser, error) {
+	u, err := c.requestURL(urlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	// The slot is held until headers arrive; reading the body is up to the caller
+	if err := c.acquire(ctx); err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	c.release()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	c.recordRateLimit(resp)
+
+	if resp.StatusCode >= 400 {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, c.handleResponse(u.Path, resp, respBody, nil)
+	}
+
+	return resp.Body, nil
+}
+
+// handleResponse turns an HTTP response into either a decoded result or an error
+func (c *Client) handleResponse(requestPath string, resp *http.Response, respBody []byte, result interface{}) error {
 	if resp.StatusCode >= 400 {
 		var apiErr Error
 		if err := json.Unmarshal(respBody, &apiErr); err != nil {
+			if resp.StatusCode == http.StatusServiceUnavailable {
+				// The controller serves an HTML maintenance page while upgrading
+				return &Error{
+					Status:      resp.StatusCode,
+					StatusName:  http.StatusText(resp.StatusCode),
+					Message:     "controller is unavailable, likely undergoing maintenance",
+					RequestPath: requestPath,
+					RawBody:     respBody,
+					err:         ErrControllerUnavailable,
+				}
+			}
 			// If we can't decode the error response, return the raw response
 			return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
 		}
+		apiErr.RawBody = respBody
 		return &apiErr
 	}
 
+	if apiErr := envelopeError(requestPath, resp.StatusCode, respBody); apiErr != nil {
+		return apiErr
+	}
+
 	if result != nil {
 		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to decode response: %w\nResponse body: %s", err, string(respBody))
+			return fmt.Errorf("failed to decode response: %w\nResponse body: %s", describeDecodeError(err, len(respBody)), string(respBody))
 		}
 	}
 
 	return nil
 }
 
+// envelopeError detects the legacy {"meta":{"rc":"error","msg":...}} envelope,
+// which the controller sometimes returns with a 2xx status, and converts it
+// to an *Error. It returns nil for any other body.
+func envelopeError(requestPath string, status int, respBody []byte) *Error {
+	if !bytes.Contains(respBody, []byte(`"rc"`)) {
+		return nil
+	}
+
+	var envelope struct {
+		Meta struct {
+			RC  string `json:"rc"`
+			Msg string `json:"msg"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(respBody, &envelope); err != nil || envelope.Meta.RC != "error" {
+		return nil
+	}
+
+	message := envelope.Meta.Msg
+	if message == "" {
+		message = "controller reported an error"
+	}
+	return &Error{
+		Status:      status,
+		StatusName:  "Error",
+		Message:     message,
+		RequestPath: requestPath,
+		RawBody:     respBody,
+	}
+}
+
+// describeDecodeError adds context to a JSON decode error, distinguishing a
+// body that was cut off (for example by a dropped connection) from one that
+// is malformed
+func describeDecodeError(err error, bodyLen int) error {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+
+	if bodyLen == 0 || syntaxErr.Offset >= int64(bodyLen) {
+		return fmt.Errorf("response truncated after %d bytes: %w", bodyLen, err)
+	}
+	return fmt.Errorf("malformed JSON at byte %d of %d: %w", syntaxErr.Offset, bodyLen, err)
+}
+
 // Error implements the error interface for UniFi API errors
 func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s (status: %d, request: %s, id: %s)",
 		e.StatusName, e.Message, e.Status, e.RequestPath, e.RequestID)
 }
+
+// Unwrap returns the underlying sentinel error, if any
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is ErrNotFound and the API responded with a 404
+func (e *Error) Is(target error) bool {
+	return target == ErrNotFound && e.Status == http.StatusNotFound
+}
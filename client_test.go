@@ -2,6 +2,7 @@ package unifi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -29,6 +30,85 @@ func TestNewClient(t *testing.T) {
 			t.Error("NewClient() error = nil, wantErr true")
 		}
 	})
+
+	t.Run("base URL validation", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			baseURL string
+			wantErr string
+		}{
+			{"http is accepted", "http://192.168.1.1:8080", ""},
+			{"upper case scheme is accepted", "HTTPS://192.168.1.1", ""},
+			{"missing scheme", "192.168.1.1", "missing scheme"},
+			{"missing scheme with port", "unifi.local:8443", "missing scheme"},
+			{"missing scheme with IP and port", "192.168.1.1:8443", "missing scheme"},
+			{"wrong scheme", "htps://192.168.1.1", "unsupported scheme \"htps\""},
+			{"ftp scheme", "ftp://192.168.1.1", "unsupported scheme \"ftp\""},
+			{"missing host", "https://", "missing host"},
+			{"missing host with path", "https:///proxy/network", "missing host"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := NewClient(tt.baseURL, WithAPIKey("test-api-key"))
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Errorf("NewClient(%q) unexpected error: %v", tt.baseURL, err)
+					}
+					return
+				}
+				if err == nil {
+					t.Fatalf("NewClient(%q) expected error containing %q, got nil", tt.baseURL, tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("NewClient(%q) error = %v, want containing %q", tt.baseURL, err, tt.wantErr)
+				}
+			})
+		}
+	})
+
+	t.Run("base URL normalization", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			baseURL string
+			want    string
+		}{
+			{"bare host", "https://192.168.1.1", "https://192.168.1.1/proxy/network/integration"},
+			{"trailing slash", "https://192.168.1.1/", "https://192.168.1.1/proxy/network/integration"},
+			{"port with prefix and trailing slash", "https://host:8443/proxy/network/integration/", "https://host:8443/proxy/network/integration"},
+			{"doubled slashes", "https://host:8443//proxy/network/integration//", "https://host:8443/proxy/network/integration"},
+			{"sub path", "https://host/unifi", "https://host/proxy/network/integration/unifi"},
+			{"encoded space", "https://host:8443/my%20site", "https://host:8443/proxy/network/integration/my%20site"},
+			{"encoded slash", "https://host/a%2Fb", "https://host/proxy/network/integration/a%2Fb"},
+			{"similar prefix is kept", "https://host/proxy/network/integrations", "https://host/proxy/network/integration/proxy/network/integrations"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client, err := NewClient(tt.baseURL, WithAPIKey("test-api-key"))
+				if err != nil {
+					t.Fatalf("NewClient() error = %v", err)
+				}
+				if got := client.baseURL.String(); got != tt.want {
+					t.Errorf("baseURL = %q, want %q", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("request URL keeps port and encoding", func(t *testing.T) {
+		client, mock := newTestClient(t, "https://host:8443/my%20site/")
+		mock.response = mockResponse(200, nil)
+
+		if err := client.do(context.Background(), http.MethodGet, "/v1/sites?limit=5", nil, nil); err != nil {
+			t.Fatalf("do() error = %v", err)
+		}
+
+		want := "https://host:8443/proxy/network/integration/my%20site/v1/sites?limit=5"
+		if got := mock.request.URL.String(); got != want {
+			t.Errorf("request URL = %q, want %q", got, want)
+		}
+	})
 }
 
 func TestClient_do(t *testing.T) {
@@ -77,6 +157,44 @@ func TestClient_do(t *testing.T) {
 		}
 	})
 
+	t.Run("maintenance page", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockRawResponse(503, "<html><body><h1>UniFi Network is starting up</h1></body></html>")
+
+		err := client.do(context.Background(), http.MethodGet, "/test", nil, nil)
+		if err == nil {
+			t.Fatal("do() error = nil, wantErr true")
+		}
+
+		if !IsUnavailable(err) {
+			t.Errorf("IsUnavailable() = false, want true for %v", err)
+		}
+
+		var apiErr *Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("do() error type = %T, want *Error", err)
+		}
+		if apiErr.Status != 503 {
+			t.Errorf("expected status 503, got %d", apiErr.Status)
+		}
+	})
+
+	t.Run("non-JSON error that is not 503", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockRawResponse(502, "<html>Bad Gateway</html>")
+
+		err := client.do(context.Background(), http.MethodGet, "/test", nil, nil)
+		if err == nil {
+			t.Fatal("do() error = nil, wantErr true")
+		}
+
+		if IsUnavailable(err) {
+			t.Errorf("IsUnavailable() = true, want false for %v", err)
+		}
+	})
+
 	t.Run("network error", func(t *testing.T) {
 		client, mock := newTestClient(t, testBaseURL)
 
@@ -231,3 +349,384 @@ func TestClient_GetApplicationInfo(t *testing.T) {
 		assertErrorResponse(t, err, 500, "Server error")
 	})
 }
+
+func TestClient_ContextCancellation(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		call   func(ctx context.Context, c *Client) error
+	}{
+		{"ListSites", "failed to list sites", func(ctx context.Context, c *Client) error {
+			_, err := c.ListSites(ctx, nil)
+			return err
+		}},
+		{"ListDevices", "failed to list devices", func(ctx context.Context, c *Client) error {
+			_, err := c.ListDevices(ctx, testSiteID, nil)
+			return err
+		}},
+		{"GetDeviceStatistics", "failed to get device statistics", func(ctx context.Context, c *Client) error {
+			_, err := c.GetDeviceStatistics(ctx, testSiteID, "abc123")
+			return err
+		}},
+		{"ListNetworkClients", "failed to list network clients", func(ctx context.Context, c *Client) error {
+			_, err := c.ListNetworkClients(ctx, testSiteID, nil)
+			return err
+		}},
+		{"ListHotspotVouchers", "failed to list hotspot vouchers", func(ctx context.Context, c *Client) error {
+			_, err := c.ListHotspotVouchers(ctx, testSiteID, nil)
+			return err
+		}},
+		{"GetApplicationInfo", "failed to get application info", func(ctx context.Context, c *Client) error {
+			_, err := c.GetApplicationInfo(ctx)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" deadline exceeded", func(t *testing.T) {
+			client, mock := newTestClient(t, testBaseURL)
+			mock.response = mockResponse(200, nil)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 0)
+			defer cancel()
+
+			err := tt.call(ctx, client)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if !strings.HasPrefix(err.Error(), tt.prefix+":") {
+				t.Errorf("expected error to start with %q, got %q", tt.prefix, err.Error())
+			}
+		})
+
+		t.Run(tt.name+" canceled", func(t *testing.T) {
+			client, mock := newTestClient(t, testBaseURL)
+			mock.response = mockResponse(200, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := tt.call(ctx, client)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("custom headers are sent", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		WithHeader("X-Forwarded-Host", "unifi.example.com")(client)
+		WithHeader("X-Request-Source", "first")(client)
+		WithHeader("X-Request-Source", "second")(client)
+		mock.response = mockResponse(200, ApplicationInfo{ApplicationVersion: "9.1.0"})
+
+		if _, err := client.GetApplicationInfo(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := mock.request.Header.Get("X-Forwarded-Host"); got != "unifi.example.com" {
+			t.Errorf("expected X-Forwarded-Host %q, got %q", "unifi.example.com", got)
+		}
+		if got := mock.request.Header.Values("X-Request-Source"); len(got) != 1 || got[0] != "second" {
+			t.Errorf("expected X-Request-Source [second], got %v", got)
+		}
+	})
+
+	t.Run("protected headers are rejected", func(t *testing.T) {
+		for _, key := range []string{"X-API-KEY", "x-api-key", "Content-Type", "Accept", "Idempotency-Key"} {
+			_, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithHeader(key, "clobbered"))
+			if err == nil {
+				t.Errorf("expected error overriding %s, got nil", key)
+			}
+		}
+	})
+
+	t.Run("protected headers cannot be clobbered", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		// Bypass NewClient's validation to check the request itself is protected
+		client.headers = http.Header{
+			"X-Api-Key":    {"clobbered"},
+			"Content-Type": {"text/plain"},
+		}
+		mock.response = mockResponse(200, ApplicationInfo{})
+
+		if _, err := client.GetApplicationInfo(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := mock.request.Header.Values("X-API-KEY"); len(got) != 1 || got[0] != client.state.apiKey {
+			t.Errorf("expected X-API-KEY [%s], got %v", client.state.apiKey, got)
+		}
+		if got := mock.request.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("expected Content-Type application/json, got %q", got)
+		}
+	})
+}
+
+func TestClient_do_DecodeErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("truncated JSON array", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		body := `{"offset":0,"limit":25,"count":2,"totalCount":2,"data":[{"id":"default","name":"Default"},{"id":"br`
+		mock.response = mockRawResponse(200, body)
+
+		_, err := client.ListSites(ctx, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		want := fmt.Sprintf("response truncated after %d bytes", len(body))
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+
+		var syntaxErr *json.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("expected wrapped *json.SyntaxError, got %T", err)
+		}
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		body := `{"offset":0,"data":[}]}`
+		mock.response = mockRawResponse(200, body)
+
+		_, err := client.ListSites(ctx, nil)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		if !strings.Contains(err.Error(), fmt.Sprintf("malformed JSON at byte 21 of %d", len(body))) {
+			t.Errorf("expected malformed JSON error, got %v", err)
+		}
+	})
+}
+
+func TestClient_do_ErrorEnvelope(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("200 with error envelope", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"meta":{"rc":"error","msg":"api.err.NoSiteContext"},"data":[]}`)
+
+		_, err := client.ListNetworks(ctx, testSiteID)
+		assertErrorResponse(t, err, 200, "api.err.NoSiteContext")
+
+		var apiErr *Error
+		if errors.As(err, &apiErr) && !strings.HasSuffix(apiErr.RequestPath, "/v1/sites/default/networks") {
+			t.Errorf("unexpected request path: %s", apiErr.RequestPath)
+		}
+	})
+
+	t.Run("error envelope without message", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"meta":{"rc":"error"}}`)
+
+		err := client.AcknowledgeAllAlarms(ctx, testSiteID)
+		assertErrorResponse(t, err, 200, "controller reported an error")
+	})
+
+	t.Run("ok envelope decodes data", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"meta":{"rc":"ok"},"data":[{"_id":"n1","name":"Default"}]}`)
+
+		networks, err := client.ListNetworks(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(networks) != 1 || networks[0].Name != "Default" {
+			t.Errorf("unexpected networks: %+v", networks)
+		}
+	})
+
+	t.Run("rc field elsewhere is ignored", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":[{"_id":"n1","name":"rc","purpose":"error"}]}`)
+
+		if _, err := client.ListNetworks(ctx, testSiteID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestClient_do_ErrorRawBody(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "API error", status: 400, body: `{"statusCode":400,"statusName":"BAD_REQUEST","message":"invalid","extra":{"field":"name"}}`},
+		{name: "maintenance page", status: 503, body: `<html>Upgrading</html>`},
+		{name: "error envelope", status: 200, body: `{"meta":{"rc":"error","msg":"api.err.Invalid"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := newTestClient(t, testBaseURL)
+			mock.response = mockRawResponse(tt.status, tt.body)
+
+			_, err := client.ListNetworks(ctx, testSiteID)
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if string(apiErr.RawBody) != tt.body {
+				t.Errorf("expected raw body %q, got %q", tt.body, apiErr.RawBody)
+			}
+		})
+	}
+}
+
+func TestWithTrailingSlash(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseURL       string
+		urlPath       string
+		trailingSlash bool
+		wantPath      string
+		wantQuery     string
+	}{
+		{
+			name:     "default has no trailing slash",
+			baseURL:  testBaseURL,
+			urlPath:  "/v1/sites/default/devices",
+			wantPath: "/proxy/network/integration/v1/sites/default/devices",
+		},
+		{
+			name:     "default strips a trailing slash",
+			baseURL:  testBaseURL + "/",
+			urlPath:  "/v1/sites/default/devices/",
+			wantPath: "/proxy/network/integration/v1/sites/default/devices",
+		},
+		{
+			name:          "enabled adds a trailing slash",
+			baseURL:       testBaseURL,
+			urlPath:       "/v1/sites/default/devices",
+			trailingSlash: true,
+			wantPath:      "/proxy/network/integration/v1/sites/default/devices/",
+		},
+		{
+			name:          "enabled does not double a slash",
+			baseURL:       testBaseURL,
+			urlPath:       "/v1/sites/default/devices/",
+			trailingSlash: true,
+			wantPath:      "/proxy/network/integration/v1/sites/default/devices/",
+		},
+		{
+			name:          "enabled keeps the query string",
+			baseURL:       testBaseURL,
+			urlPath:       "/v1/sites/default/devices?limit=200",
+			trailingSlash: true,
+			wantPath:      "/proxy/network/integration/v1/sites/default/devices/",
+			wantQuery:     "limit=200",
+		},
+		{
+			name:          "enabled with escaped segment",
+			baseURL:       testBaseURL,
+			urlPath:       "/v1/sites/default/clients/a%2Fb",
+			trailingSlash: true,
+			wantPath:      "/proxy/network/integration/v1/sites/default/clients/a%2Fb/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.baseURL, WithAPIKey("test-api-key"), WithTrailingSlash(tt.trailingSlash))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			u, err := client.requestURL(tt.urlPath)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := u.EscapedPath(); got != tt.wantPath {
+				t.Errorf("expected path %s, got %s", tt.wantPath, got)
+			}
+			if u.RawQuery != tt.wantQuery {
+				t.Errorf("expected query %q, got %q", tt.wantQuery, u.RawQuery)
+			}
+		})
+	}
+
+	t.Run("applied to requests", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		WithTrailingSlash(true)(client)
+		mock.response = mockResponse(200, ApplicationInfo{})
+
+		if _, err := client.GetApplicationInfo(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := mock.request.URL.Path; !strings.HasSuffix(got, "/") {
+			t.Errorf("expected request path to end in a slash, got %s", got)
+		}
+	})
+}
+
+func TestWithRawBaseURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		wantPath string
+	}{
+		{
+			name:     "proxy path",
+			baseURL:  "https://proxy.example.com/unifi-api",
+			wantPath: "/unifi-api/v1/sites/default/devices",
+		},
+		{
+			name:     "no path",
+			baseURL:  "https://proxy.example.com",
+			wantPath: "/v1/sites/default/devices",
+		},
+		{
+			name:     "existing prefix is not doubled or trimmed",
+			baseURL:  "https://proxy.example.com/proxy/network/integration",
+			wantPath: "/proxy/network/integration/v1/sites/default/devices",
+		},
+		{
+			name:     "escaped segment",
+			baseURL:  "https://proxy.example.com/site%2Fa",
+			wantPath: "/site%2Fa/v1/sites/default/devices",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockTransport{response: mockResponse(200, ListDevicesResponse{})}
+			client, err := NewClient(tt.baseURL,
+				WithAPIKey("test-api-key"),
+				WithHTTPClient(&http.Client{Transport: mock}),
+				WithRawBaseURL(),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := client.baseURL.String(); got != tt.baseURL {
+				t.Errorf("expected base URL %s to be untouched, got %s", tt.baseURL, got)
+			}
+
+			if _, err := client.ListDevices(context.Background(), testSiteID, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := mock.request.URL.EscapedPath(); got != tt.wantPath {
+				t.Errorf("expected request path %s, got %s", tt.wantPath, got)
+			}
+		})
+	}
+
+	t.Run("cannot be toggled on a clone", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if _, err := client.Clone(WithRawBaseURL()); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
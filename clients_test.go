@@ -2,7 +2,13 @@ package unifi
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClient_ListNetworkClients(t *testing.T) {
@@ -69,6 +75,34 @@ func TestClient_ListNetworkClients(t *testing.T) {
 		}
 	})
 
+	t.Run("count mismatch is corrected", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, ListNetworkClientsResponse{
+			Offset:     0,
+			Limit:      25,
+			Count:      25,
+			TotalCount: 3,
+			Data: []NetworkClient{
+				{ID: "a"},
+				{ID: "b"},
+				{ID: "c"},
+			},
+		})
+
+		result, err := client.ListNetworkClients(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Count != 3 {
+			t.Errorf("expected count to be corrected to 3, got %d", result.Count)
+		}
+		if result.TotalCount != 3 {
+			t.Errorf("expected total count 3, got %d", result.TotalCount)
+		}
+	})
+
 	t.Run("invalid limit", func(t *testing.T) {
 		client, _ := newTestClient(t, testBaseURL)
 
@@ -139,6 +173,31 @@ func TestClient_GetNetworkClient(t *testing.T) {
 		}
 	})
 
+	t.Run("with IPv6 addresses", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockRawResponse(200, `{"data":[{
+			"id": "abc123",
+			"ipAddress": "192.168.1.100",
+			"ipv6_addresses": ["fe80::1", "2001:db8::1"]
+		}]}`)
+
+		result, err := client.GetNetworkClient(ctx, testSiteID, clientID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []string{"fe80::1", "2001:db8::1"}
+		if len(result.IPv6Addresses) != len(want) {
+			t.Fatalf("expected %d IPv6 addresses, got %d", len(want), len(result.IPv6Addresses))
+		}
+		for i, addr := range want {
+			if result.IPv6Addresses[i] != addr {
+				t.Errorf("expected IPv6 address %s, got %s", addr, result.IPv6Addresses[i])
+			}
+		}
+	})
+
 	t.Run("client not found", func(t *testing.T) {
 		client, mock := newTestClient(t, testBaseURL)
 
@@ -170,3 +229,600 @@ func TestClient_GetNetworkClient(t *testing.T) {
 		assertErrorResponse(t, err, 404, "Site not found")
 	})
 }
+
+func TestClient_SetClientBandwidthProfile(t *testing.T) {
+	ctx := context.Background()
+	clientID := "abc123"
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, struct {
+			Data []NetworkClient `json:"data"`
+		}{
+			Data: []NetworkClient{{ID: clientID}},
+		})
+
+		if err := client.SetClientBandwidthProfile(ctx, testSiteID, clientID, "g2"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if mock.request.Method != http.MethodPatch {
+			t.Errorf("expected method %s, got %s", http.MethodPatch, mock.request.Method)
+		}
+		if got := mock.request.URL.Path; !strings.HasSuffix(got, "/v1/sites/default/clients/abc123") {
+			t.Errorf("unexpected request path: %s", got)
+		}
+
+		var body map[string]interface{}
+		decodeRequestBody(t, mock.request, &body)
+		if want := map[string]interface{}{"usergroup_id": "g2"}; !reflect.DeepEqual(body, want) {
+			t.Errorf("expected body %v, got %v", want, body)
+		}
+	})
+
+	t.Run("missing IDs", func(t *testing.T) {
+		for _, ids := range [][2]string{{"", "g2"}, {clientID, ""}} {
+			client, mock := newTestClient(t, testBaseURL)
+
+			if err := client.SetClientBandwidthProfile(ctx, testSiteID, ids[0], ids[1]); err == nil {
+				t.Errorf("expected error for client %q profile %q, got nil", ids[0], ids[1])
+			}
+			if mock.request != nil {
+				t.Error("expected no request")
+			}
+		}
+	})
+}
+
+func TestClient_SetClientFixedIP(t *testing.T) {
+	ctx := context.Background()
+	clientID := "abc123"
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, struct {
+			Data []NetworkClient `json:"data"`
+		}{
+			Data: []NetworkClient{{ID: clientID, UseFixedIP: true, FixedIP: "192.168.1.50"}},
+		})
+
+		if err := client.SetClientFixedIP(ctx, testSiteID, clientID, "192.168.1.50"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if mock.request.Method != http.MethodPatch {
+			t.Errorf("expected method %s, got %s", http.MethodPatch, mock.request.Method)
+		}
+
+		var body map[string]interface{}
+		decodeRequestBody(t, mock.request, &body)
+		if body["use_fixedip"] != true {
+			t.Errorf("expected use_fixedip true, got %v", body["use_fixedip"])
+		}
+		if body["fixed_ip"] != "192.168.1.50" {
+			t.Errorf("expected fixed_ip %q, got %v", "192.168.1.50", body["fixed_ip"])
+		}
+		if _, ok := body["name"]; ok {
+			t.Error("expected name to be omitted from request body")
+		}
+	})
+
+	t.Run("invalid IP", func(t *testing.T) {
+		for _, ip := range []string{"", "not-an-ip", "192.168.1.256", "192.168.1"} {
+			client, mock := newTestClient(t, testBaseURL)
+
+			if err := client.SetClientFixedIP(ctx, testSiteID, clientID, ip); err == nil {
+				t.Errorf("expected error for IP %q, got nil", ip)
+			}
+			if mock.request != nil {
+				t.Errorf("expected no request for IP %q", ip)
+			}
+		}
+	})
+
+	t.Run("client not found", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(404, Error{
+			Status:     404,
+			StatusName: "Not Found",
+			Message:    "Client not found",
+		})
+
+		err := client.SetClientFixedIP(ctx, testSiteID, "nonexistent", "192.168.1.50")
+		assertErrorResponse(t, err, 404, "Client not found")
+	})
+}
+
+func TestClient_ClearClientFixedIP(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, struct {
+			Data []NetworkClient `json:"data"`
+		}{
+			Data: []NetworkClient{{ID: "abc123"}},
+		})
+
+		if err := client.ClearClientFixedIP(ctx, testSiteID, "abc123"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var body map[string]interface{}
+		decodeRequestBody(t, mock.request, &body)
+		if body["use_fixedip"] != false {
+			t.Errorf("expected use_fixedip false, got %v", body["use_fixedip"])
+		}
+		if _, ok := body["fixed_ip"]; ok {
+			t.Error("expected fixed_ip to be omitted from request body")
+		}
+	})
+}
+
+func TestClient_SetClientName(t *testing.T) {
+	ctx := context.Background()
+	clientID := "abc123"
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, struct {
+			Data []NetworkClient `json:"data"`
+		}{
+			Data: []NetworkClient{{ID: clientID, Name: "Living Room TV"}},
+		})
+
+		result, err := client.SetClientName(ctx, testSiteID, clientID, "Living Room TV")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Name != "Living Room TV" {
+			t.Errorf("expected client name %q, got %q", "Living Room TV", result.Name)
+		}
+
+		var body map[string]interface{}
+		decodeRequestBody(t, mock.request, &body)
+		if body["name"] != "Living Room TV" {
+			t.Errorf("expected name %q, got %v", "Living Room TV", body["name"])
+		}
+		if len(body) != 1 {
+			t.Errorf("expected only name in request body, got %v", body)
+		}
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if _, err := client.SetClientName(ctx, testSiteID, clientID, "  "); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("client not found", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(404, Error{
+			Status:     404,
+			StatusName: "Not Found",
+			Message:    "Client not found",
+		})
+
+		_, err := client.SetClientName(ctx, testSiteID, "nonexistent", "Living Room TV")
+		assertErrorResponse(t, err, 404, "Client not found")
+	})
+}
+
+func TestClient_ListNetworkClients_LimitMax(t *testing.T) {
+	client, mock := newTestClient(t, testBaseURL)
+	mock.response = mockResponse(200, ListNetworkClientsResponse{})
+
+	if _, err := client.ListNetworkClients(context.Background(), testSiteID, &ListNetworkClientsParams{Limit: LimitMax}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := mock.request.URL.Query().Get("limit"); got != "200" {
+		t.Errorf("expected limit=200, got %q", got)
+	}
+}
+
+func TestClient_ListNetworkClients_Offline(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		params    *ListNetworkClientsParams
+		wantQuery string
+	}{
+		{"active only by default", &ListNetworkClientsParams{}, ""},
+		{"include offline", &ListNetworkClientsParams{IncludeOffline: true}, "includeOffline=true"},
+		{"within whole hours", &ListNetworkClientsParams{Within: 24 * time.Hour}, "includeOffline=true&withinHours=24"},
+		{"within rounds up", &ListNetworkClientsParams{Within: 90 * time.Minute}, "includeOffline=true&withinHours=2"},
+		{
+			"within with pagination",
+			&ListNetworkClientsParams{Limit: 50, IncludeOffline: true, Within: 7 * 24 * time.Hour},
+			"includeOffline=true&limit=50&withinHours=168",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := newTestClient(t, testBaseURL)
+			mock.response = mockResponse(200, ListNetworkClientsResponse{})
+
+			if _, err := client.ListNetworkClients(ctx, testSiteID, tt.params); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := mock.request.URL.RawQuery; got != tt.wantQuery {
+				t.Errorf("expected query %q, got %q", tt.wantQuery, got)
+			}
+		})
+	}
+
+	t.Run("negative within", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		if _, err := client.ListNetworkClients(ctx, testSiteID, &ListNetworkClientsParams{Within: -time.Hour}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if mock.request != nil {
+			t.Error("expected no request")
+		}
+	})
+}
+
+func TestNetworkClient_UnmarshalJSON(t *testing.T) {
+	t.Run("integration v1 shape", func(t *testing.T) {
+		data := []byte(`{
+			"type": "WIRED",
+			"id": "4b9a5d1c-7c1e-4c2e-9a3a-0c8a4b1d2e3f",
+			"name": "NAS",
+			"connectedAt": "2024-03-01T10:00:00Z",
+			"ipAddress": "192.168.1.20",
+			"macAddress": "aa:bb:cc:dd:ee:ff",
+			"uplinkDeviceId": "switch1",
+			"siteId": "site1",
+			"ipv6Addresses": ["fe80::1"]
+		}`)
+
+		var client NetworkClient
+		if err := json.Unmarshal(data, &client); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if client.ID != "4b9a5d1c-7c1e-4c2e-9a3a-0c8a4b1d2e3f" {
+			t.Errorf("unexpected ID %q", client.ID)
+		}
+		if client.MACAddress != "aa:bb:cc:dd:ee:ff" {
+			t.Errorf("unexpected MAC %q", client.MACAddress)
+		}
+		if client.SiteID != "site1" {
+			t.Errorf("expected siteId to populate SiteID, got %q", client.SiteID)
+		}
+		if len(client.IPv6Addresses) != 1 {
+			t.Errorf("expected ipv6Addresses to populate IPv6Addresses, got %v", client.IPv6Addresses)
+		}
+		if !client.IsWired {
+			t.Error("expected WIRED type to imply IsWired")
+		}
+	})
+
+	t.Run("legacy v2 shape", func(t *testing.T) {
+		data := []byte(`{
+			"_id": "5f1e2d3c4b5a69788796a5b4",
+			"mac": "11:22:33:44:55:66",
+			"ip": "192.168.1.30",
+			"hostname": "laptop",
+			"name": "Laptop",
+			"site_id": "5f0000000000000000000001",
+			"network": "LAN",
+			"network_name": "Default",
+			"network_id": "net1",
+			"oui": "Apple",
+			"last_seen": 1709287200,
+			"uptime": 3600,
+			"is_wired": false,
+			"is_guest": true,
+			"rx_bytes": 1024,
+			"tx_bytes": 2048,
+			"signal": -55,
+			"noise": -95,
+			"essid": "Guest",
+			"use_fixedip": true,
+			"fixed_ip": "192.168.1.30"
+		}`)
+
+		var client NetworkClient
+		if err := json.Unmarshal(data, &client); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if client.ID != "5f1e2d3c4b5a69788796a5b4" {
+			t.Errorf("expected _id to populate ID, got %q", client.ID)
+		}
+		if client.MACAddress != "11:22:33:44:55:66" {
+			t.Errorf("expected mac to populate MACAddress, got %q", client.MACAddress)
+		}
+		if client.IPAddress != "192.168.1.30" {
+			t.Errorf("expected ip to populate IPAddress, got %q", client.IPAddress)
+		}
+		if client.SiteID != "5f0000000000000000000001" {
+			t.Errorf("unexpected SiteID %q", client.SiteID)
+		}
+		if client.TxBytes != 2048 {
+			t.Errorf("expected tx bytes 2048, got %d", client.TxBytes)
+		}
+		if client.IsWired || !client.IsGuest {
+			t.Errorf("expected wireless guest client, got IsWired=%v IsGuest=%v", client.IsWired, client.IsGuest)
+		}
+		if !client.UseFixedIP || client.FixedIP != "192.168.1.30" {
+			t.Errorf("unexpected fixed IP settings %v %q", client.UseFixedIP, client.FixedIP)
+		}
+	})
+
+	t.Run("primary key wins over alternate", func(t *testing.T) {
+		var client NetworkClient
+		if err := json.Unmarshal([]byte(`{"id": "primary", "_id": "legacy"}`), &client); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.ID != "primary" {
+			t.Errorf("expected ID %q, got %q", "primary", client.ID)
+		}
+	})
+
+	t.Run("invalid alternate value", func(t *testing.T) {
+		var client NetworkClient
+		if err := json.Unmarshal([]byte(`{"lastSeen": "yesterday"}`), &client); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestClient_BlockClients(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sends one normalized command per MAC", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		client.concurrency = 1 // mockTransport is not safe for concurrent use
+		mock.response = mockResponse(200, nil)
+
+		macs := []string{"00-11-22-AA-BB-CC", "0011.2233.4455"}
+		if err := client.BlockClients(ctx, testSiteID, macs); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+		}
+		want := []ClientCommand{
+			{Cmd: "block-sta", MAC: "00:11:22:aa:bb:cc"},
+			{Cmd: "block-sta", MAC: "00:11:22:33:44:55"},
+		}
+		for i, req := range mock.requests {
+			if req.Method != http.MethodPost {
+				t.Errorf("request %d: expected POST, got %s", i, req.Method)
+			}
+			if !strings.HasSuffix(req.URL.Path, "/v1/sites/default/cmd/stamgr") {
+				t.Errorf("request %d: unexpected path %s", i, req.URL.Path)
+			}
+			var got ClientCommand
+			decodeRequestBody(t, req, &got)
+			if got != want[i] {
+				t.Errorf("request %d: expected body %+v, got %+v", i, want[i], got)
+			}
+		}
+	})
+
+	t.Run("partial failure joins errors and continues", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		client.concurrency = 1
+		mock.responses = []*http.Response{
+			mockResponse(200, nil),
+			mockResponse(400, Error{Status: 400, StatusName: "Bad Request", Message: "Unknown station"}),
+		}
+		mock.response = mockResponse(200, nil)
+
+		macs := []string{"00:11:22:33:44:01", "00:11:22:33:44:02", "00:11:22:33:44:03"}
+		err := client.BlockClients(ctx, testSiteID, macs)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		if len(mock.requests) != 3 {
+			t.Errorf("expected all 3 MACs to be attempted, got %d requests", len(mock.requests))
+		}
+		if !strings.Contains(err.Error(), "00:11:22:33:44:02") {
+			t.Errorf("expected error to name the failed MAC, got %v", err)
+		}
+		if strings.Contains(err.Error(), "00:11:22:33:44:01") || strings.Contains(err.Error(), "00:11:22:33:44:03") {
+			t.Errorf("expected error to name only the failed MAC, got %v", err)
+		}
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Status != 400 {
+			t.Errorf("expected wrapped 400 API error, got %v", err)
+		}
+	})
+
+	t.Run("invalid MACs are rejected before sending", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		err := client.BlockClients(ctx, testSiteID, []string{"00:11:22:33:44:55", "bogus", "also-bogus"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), `"bogus"`) || !strings.Contains(err.Error(), `"also-bogus"`) {
+			t.Errorf("expected both invalid MACs in error, got %v", err)
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("no MACs", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if err := client.BlockClients(ctx, testSiteID, nil); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestClient_UnblockClients(t *testing.T) {
+	client, mock := newTestClient(t, testBaseURL)
+	mock.response = mockResponse(200, nil)
+
+	if err := client.UnblockClients(context.Background(), testSiteID, []string{"00:11:22:33:44:55"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got ClientCommand
+	decodeRequestBody(t, mock.request, &got)
+	if got != (ClientCommand{Cmd: "unblock-sta", MAC: "00:11:22:33:44:55"}) {
+		t.Errorf("unexpected body: %+v", got)
+	}
+}
+
+func TestClient_GetNetworkClientByIP(t *testing.T) {
+	ctx := context.Background()
+
+	clients := []NetworkClient{
+		{ID: "old", IPAddress: "192.168.1.50", LastSeen: 1000},
+		{ID: "other", IPAddress: "192.168.1.51", LastSeen: 3000},
+		{ID: "new", IPAddress: "192.168.1.50", LastSeen: 2000},
+		{ID: "v6", IPAddress: "192.168.1.52", IPv6Addresses: []string{"fe80::1"}, LastSeen: 500},
+	}
+	listResponse := ListNetworkClientsResponse{
+		Count:      len(clients),
+		TotalCount: len(clients),
+		Data:       clients,
+	}
+
+	t.Run("most recently seen match wins", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, listResponse)
+
+		result, err := client.GetNetworkClientByIP(ctx, testSiteID, "192.168.1.50")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "new" {
+			t.Errorf("expected client new, got %s", result.ID)
+		}
+	})
+
+	t.Run("matches IPv6 address", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, listResponse)
+
+		result, err := client.GetNetworkClientByIP(ctx, testSiteID, "FE80:0:0::1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "v6" {
+			t.Errorf("expected client v6, got %s", result.ID)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, listResponse)
+
+		_, err := client.GetNetworkClientByIP(ctx, testSiteID, "10.0.0.1")
+		if !IsNotFound(err) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("invalid IP", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		_, err := client.GetNetworkClientByIP(ctx, testSiteID, "192.168.1")
+		if err == nil {
+			t.Fatal("expected error for invalid IP, got nil")
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+}
+
+func TestClient_WeakClients(t *testing.T) {
+	ctx := context.Background()
+
+	clients := []NetworkClient{
+		{ID: "wired", Type: "WIRED", IsWired: true},
+		{ID: "vpn", Type: "VPN"},
+		{ID: "strong", Type: "WIRELESS", SNR: 40},
+		{ID: "weak", Type: "WIRELESS", SNR: 15},
+		{ID: "weakest", Type: "WIRELESS", SNR: 5},
+		{ID: "threshold", Type: "WIRELESS", SNR: 20},
+		{ID: "legacy-weak", SNR: 10},
+		{ID: "legacy-wired", IsWired: true, SNR: 0},
+		{ID: "unreported", Type: "WIRELESS"},
+		{ID: "legacy-unreported"},
+	}
+
+	client, mock := newTestClient(t, testBaseURL)
+	mock.response = mockResponse(200, ListNetworkClientsResponse{
+		Count:      len(clients),
+		TotalCount: len(clients),
+		Data:       clients,
+	})
+
+	weak, err := client.WeakClients(ctx, testSiteID, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, wc := range weak {
+		got = append(got, wc.ID)
+	}
+	want := []string{"weakest", "legacy-weak", "weak"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected weak clients %v, got %v", want, got)
+	}
+}
+
+func TestClient_SSIDClientCounts(t *testing.T) {
+	ctx := context.Background()
+
+	clients := []NetworkClient{
+		{ID: "a", Type: "WIRELESS", SSID: "Home"},
+		{ID: "b", Type: "WIRELESS", SSID: "Guest"},
+		{ID: "c", Type: "WIRELESS", SSID: "Home"},
+		{ID: "d", SSID: "Home"},
+		{ID: "wired", Type: "WIRED", IsWired: true, SSID: "Home"},
+		{ID: "legacy-wired", IsWired: true},
+		{ID: "vpn", Type: "VPN"},
+		{ID: "no-ssid", Type: "WIRELESS"},
+	}
+
+	client, mock := newTestClient(t, testBaseURL)
+	mock.response = mockResponse(200, ListNetworkClientsResponse{
+		Count:      len(clients),
+		TotalCount: len(clients),
+		Data:       clients,
+	})
+
+	counts, err := client.SSIDClientCounts(ctx, testSiteID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]int{"Home": 3, "Guest": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("expected counts %v, got %v", want, counts)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(500, Error{Status: 500, Message: "Internal Server Error"})
+
+		if _, err := client.SSIDClientCounts(ctx, testSiteID); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
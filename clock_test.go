@@ -0,0 +1,81 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithClock(t *testing.T) {
+	t.Run("defaults to real time", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+		if _, ok := client.clock.(realClock); !ok {
+			t.Errorf("expected realClock, got %T", client.clock)
+		}
+	})
+
+	t.Run("nil clock", func(t *testing.T) {
+		_, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithClock(nil))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestClient_sleep(t *testing.T) {
+	t.Run("returns once the fake clock advances", func(t *testing.T) {
+		clock := newFakeClock()
+		client, _ := newTestClient(t, testBaseURL)
+		client.clock = clock
+
+		done := make(chan error, 1)
+		go func() {
+			done <- client.sleep(context.Background(), time.Minute)
+		}()
+
+		clock.waitForWaiters(t, 1)
+		clock.Advance(30 * time.Second)
+		select {
+		case <-done:
+			t.Fatal("sleep returned before its deadline")
+		default:
+		}
+
+		clock.Advance(30 * time.Second)
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		clock := newFakeClock()
+		client, _ := newTestClient(t, testBaseURL)
+		client.clock = clock
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- client.sleep(ctx, time.Hour)
+		}()
+
+		clock.waitForWaiters(t, 1)
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("non-positive duration does not wait", func(t *testing.T) {
+		clock := newFakeClock()
+		client, _ := newTestClient(t, testBaseURL)
+		client.clock = clock
+
+		if err := client.sleep(context.Background(), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(clock.Sleeps()) != 0 {
+			t.Errorf("expected no waits on the clock, got %v", clock.Sleeps())
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package unifi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// Clone returns a copy of the client with opts applied on top of its current
+// settings, leaving the original untouched. Use it when one call needs, for
+// example, a different HTTP client or retry policy without mutating a client
+// shared by other goroutines.
+//
+// The clone starts with the original's API key and last rate limit but keeps
+// its own copy, so SetAPIKey on either does not affect the other. It shares
+// the original's HTTP transport and in-flight request limit unless an option
+// changes them, so connections are reused and a shared limit keeps applying
+// across both. The list and device model caches are shared only when the
+// clone keeps the original's API key and headers (and, for the list cache,
+// its TTL); otherwise the clone gets its own, so it never serves responses
+// fetched with different credentials. Clone
+// returns an error if the options leave the clone in a state NewClient would
+// reject.
+func (c *Client) Clone(opts ...ClientOption) (*Client, error) {
+	clone := c.copy()
+	for _, opt := range opts {
+		opt(clone)
+	}
+
+	err := clone.validate()
+	if err == nil && clone.rawBaseURL != c.rawBaseURL {
+		// The original URL is gone once the prefix has been applied
+		err = fmt.Errorf("raw base URL cannot be changed on a clone")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	credentialsChanged := clone.state.apiKey != c.state.key() ||
+		!reflect.DeepEqual(clone.headers, c.headers)
+	if credentialsChanged {
+		clone.deviceModels = newDeviceModelCache()
+	}
+
+	if clone.listCacheTTL != c.listCacheTTL || credentialsChanged {
+		clone.listCache = nil
+		if clone.listCacheTTL > 0 {
+			clone.listCache = newListCache(clone.listCacheTTL)
+		}
+	}
+
+	if clone.maxInFlight != c.maxInFlight {
+		clone.inFlight = nil
+		if clone.maxInFlight > 0 {
+			clone.inFlight = make(chan struct{}, clone.maxInFlight)
+		}
+	}
+
+	// Follow NewClient: insecure always gets its own TLS config, and a cleared
+	// HTTP client falls back to a dedicated transport. Toggling insecure on a
+	// shared HTTP client needs a new transport either way.
+	httpChanged := clone.httpClient != c.httpClient
+	insecureChanged := clone.insecure != c.insecure
+	if clone.httpClient == nil ||
+		(clone.insecure && (httpChanged || insecureChanged)) ||
+		(insecureChanged && !httpChanged) {
+		clone.httpClient = &http.Client{
+			Transport: newDefaultTransport(clone.insecure),
+		}
+	}
+
+	return clone, nil
+}
+
+// copy returns a shallow copy of c with its mutable fields duplicated so
+// options applied to the copy cannot affect c
+func (c *Client) copy() *Client {
+	clone := *c
+
+	baseURL := *c.baseURL
+	clone.baseURL = &baseURL
+	clone.headers = c.headers.Clone()
+	clone.state = c.state.copy()
+
+	return &clone
+}
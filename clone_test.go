@@ -0,0 +1,160 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClone(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("overrides apply to the clone only", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		clone, err := client.Clone(
+			WithAPIKey("other-key"),
+			WithMaxRetries(3),
+			WithHeader("X-Tenant", "lab"),
+			WithConcurrency(2),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if clone.state.apiKey != "other-key" || client.state.apiKey != "test-api-key" {
+			t.Errorf("expected api keys other-key/test-api-key, got %q/%q", clone.state.apiKey, client.state.apiKey)
+		}
+		if clone.maxRetries != 3 || client.maxRetries != 0 {
+			t.Errorf("expected max retries 3/0, got %d/%d", clone.maxRetries, client.maxRetries)
+		}
+		if clone.concurrency != 2 || client.concurrency != defaultConcurrency {
+			t.Errorf("expected concurrency 2/%d, got %d/%d", defaultConcurrency, clone.concurrency, client.concurrency)
+		}
+		if clone.headers.Get("X-Tenant") != "lab" || client.headers.Get("X-Tenant") != "" {
+			t.Errorf("expected header only on the clone, got %v/%v", clone.headers, client.headers)
+		}
+	})
+
+	t.Run("shares transport by default", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ApplicationInfo{ApplicationVersion: "9.1.0"})
+
+		clone, err := client.Clone(WithHeader("X-Tenant", "lab"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if clone.httpClient != client.httpClient {
+			t.Fatal("expected clone to share the HTTP client")
+		}
+		if _, err := clone.GetApplicationInfo(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := mock.request.Header.Get("X-Tenant"); got != "lab" {
+			t.Errorf("expected clone header on request, got %q", got)
+		}
+		if got := mock.request.Header.Get("X-API-KEY"); got != "test-api-key" {
+			t.Errorf("expected inherited api key, got %q", got)
+		}
+	})
+
+	t.Run("custom http client", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+		httpClient := &http.Client{Timeout: time.Second, Transport: client.httpClient.Transport}
+
+		clone, err := client.Clone(WithHTTPClient(httpClient))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if clone.httpClient != httpClient {
+			t.Error("expected clone to use the given HTTP client")
+		}
+		if client.httpClient.Timeout != 0 {
+			t.Errorf("expected original timeout unchanged, got %v", client.httpClient.Timeout)
+		}
+	})
+
+	t.Run("insecure gets its own transport", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		clone, err := client.Clone(WithInsecure(true))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if clone.httpClient == client.httpClient {
+			t.Fatal("expected insecure clone to get its own HTTP client")
+		}
+		transport, ok := clone.httpClient.Transport.(*http.Transport)
+		if !ok || transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+			t.Error("expected insecure TLS config on the clone")
+		}
+	})
+
+	t.Run("list cache and request limit", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		cached, err := client.Clone(WithListCache(time.Minute), WithMaxConcurrentRequests(2))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cached.listCache == nil || client.listCache != nil {
+			t.Error("expected list cache on the clone only")
+		}
+		if cap(cached.inFlight) != 2 || client.inFlight != nil {
+			t.Error("expected request limit on the clone only")
+		}
+
+		shared, err := cached.Clone(WithMaxRetries(2))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if shared.listCache != cached.listCache || shared.inFlight != cached.inFlight {
+			t.Error("expected unchanged cache and request limit to be shared")
+		}
+
+		for name, opt := range map[string]ClientOption{
+			"api key": WithAPIKey("other-key"),
+			"header":  WithHeader("X-Tenant", "lab"),
+		} {
+			other, err := cached.Clone(opt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if other.listCache == nil || other.listCache == cached.listCache {
+				t.Errorf("expected a clone with a different %s not to share the cache", name)
+			}
+			if other.inFlight != cached.inFlight {
+				t.Errorf("expected a clone with a different %s to share the request limit", name)
+			}
+		}
+	})
+
+	t.Run("base URL is copied", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		clone, err := client.Clone()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		clone.baseURL.Host = "other:8443"
+		if client.baseURL.Host == "other:8443" {
+			t.Error("expected original base URL to be unaffected")
+		}
+	})
+
+	t.Run("invalid overrides are rejected", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		clone, err := client.Clone(WithAPIKey("other-key"), WithConcurrency(0))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if clone != nil {
+			t.Errorf("expected nil client, got %+v", clone)
+		}
+		if client.state.apiKey != "test-api-key" || client.concurrency != defaultConcurrency {
+			t.Errorf("expected original settings, got api key %q, concurrency %d", client.state.apiKey, client.concurrency)
+		}
+	})
+}
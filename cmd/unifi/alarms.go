@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+func alarmsCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "alarms",
+		Aliases: []string{"a"},
+		Usage:   "Manage UniFi alarms",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "ack",
+				Usage: "Acknowledge an alarm, or all alarms with --all",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.StringFlag{
+						Name:  "id",
+						Usage: "Alarm ID",
+					},
+					&cli.BoolFlag{
+						Name:  "all",
+						Usage: "Acknowledge all alarms for the site",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if c.Bool("all") == c.IsSet("id") {
+						return fmt.Errorf("exactly one of --id or --all must be provided")
+					}
+
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if c.Bool("all") {
+						if err := client.AcknowledgeAllAlarms(ctx, c.String("site")); err != nil {
+							return fmt.Errorf("failed to acknowledge alarms: %w", err)
+						}
+						fmt.Printf("Successfully acknowledged all alarms for site %s\n", c.String("site"))
+						return nil
+					}
+
+					if err := client.AcknowledgeAlarm(ctx, c.String("site"), c.String("id")); err != nil {
+						return fmt.Errorf("failed to acknowledge alarm: %w", err)
+					}
+					fmt.Printf("Successfully acknowledged alarm %s\n", c.String("id"))
+					return nil
+				},
+			},
+		},
+	}
+}
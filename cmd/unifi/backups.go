@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+func backupsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "backups",
+		Usage: "List and download controller configuration backups",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List backups stored on the controller",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					backups, err := client.ListBackups(ctx)
+					if err != nil {
+						return fmt.Errorf("failed to list backups: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(backups)
+					}
+
+					// Table output
+					fmt.Printf("%-24s %-40s %-10s %-17s %-10s\n", "ID", "FILENAME", "SIZE", "CREATED", "VERSION")
+					fmt.Println(strings.Repeat("-", 105))
+					for _, backup := range backups {
+						fmt.Printf("%-24s %-40s %-10s %-17s %-10s\n",
+							truncateString(backup.ID, 23),
+							truncateString(backup.Filename, 39),
+							formatSize(backup.Size),
+							backup.Created().Format("2006-01-02 15:04"),
+							backup.Version,
+						)
+					}
+
+					fmt.Printf("\nTotal: %d backup(s)\n", len(backups))
+					return nil
+				},
+			},
+			{
+				Name:  "download",
+				Usage: "Download a backup file",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Backup ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "out",
+						Aliases:  []string{"o"},
+						Usage:    "Output file, or - for stdout",
+						Required: true,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					body, err := client.DownloadBackup(ctx, c.String("id"))
+					if err != nil {
+						return fmt.Errorf("failed to download backup: %w", err)
+					}
+					defer func() {
+						_ = body.Close()
+					}()
+
+					if c.String("out") == "-" {
+						_, err = io.Copy(os.Stdout, body)
+						return err
+					}
+
+					out, err := os.Create(c.String("out"))
+					if err != nil {
+						return fmt.Errorf("failed to create output file: %w", err)
+					}
+
+					n, err := io.Copy(out, body)
+					if closeErr := out.Close(); err == nil {
+						err = closeErr
+					}
+					if err != nil {
+						return fmt.Errorf("failed to write backup: %w", err)
+					}
+
+					fmt.Fprintf(os.Stderr, "Wrote %s to %s\n", formatSize(n), c.String("out"))
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// formatSize renders a byte count using binary units (KiB, MiB, ...)
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -1,11 +1,12 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/klauern/unifi-network-go"
 	"github.com/urfave/cli/v2"
@@ -29,7 +30,7 @@ func clientsCommand() *cli.Command {
 					},
 					&cli.IntFlag{
 						Name:  "limit",
-						Usage: "Maximum number of clients to return (0-200)",
+						Usage: "Maximum number of clients to return (0-200, -1 for max)",
 						Value: 25,
 					},
 					&cli.IntFlag{
@@ -37,47 +38,535 @@ func clientsCommand() *cli.Command {
 						Usage: "Starting offset for pagination",
 						Value: 0,
 					},
+					&cli.BoolFlag{
+						Name:  "include-offline",
+						Usage: "Also list known clients that are not connected",
+					},
+					&cli.DurationFlag{
+						Name:  "within",
+						Usage: "Only list offline clients seen within this window (e.g. 24h); implies --include-offline",
+					},
+					&cli.StringFlag{
+						Name:  "group-by",
+						Usage: "Group table output (network)",
+					},
 					&cli.BoolFlag{
 						Name:  "json",
 						Usage: "Output in JSON format",
 						Value: false,
 					},
+					formatFlag(),
 				},
 				Action: func(c *cli.Context) error {
+					groupBy := c.String("group-by")
+					if groupBy != "" && groupBy != "network" {
+						return fmt.Errorf("invalid group-by value %q (supported: network)", groupBy)
+					}
+
 					client, err := createClient(c)
 					if err != nil {
 						return err
 					}
 
+					format, err := outputFormat(c)
+					if err != nil {
+						return err
+					}
+
 					params := &unifi.ListNetworkClientsParams{
-						Limit:  c.Int("limit"),
-						Offset: c.Int("offset"),
+						Limit:          c.Int("limit"),
+						Offset:         c.Int("offset"),
+						IncludeOffline: c.Bool("include-offline"),
+						Within:         c.Duration("within"),
+					}
+
+					if err := rejectNDJSONFlags(c, format, "limit", "group-by"); err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if format == formatNDJSON {
+						params.Limit = unifi.LimitMax
+						return writeNDJSON(os.Stdout, client.IterNetworkClients(ctx, c.String("site"), params))
 					}
 
-					ctx := context.Background()
 					resp, err := client.ListNetworkClients(ctx, c.String("site"), params)
 					if err != nil {
 						return fmt.Errorf("failed to list network clients: %w", err)
 					}
 
-					if c.Bool("json") {
+					if format == formatJSON {
 						return json.NewEncoder(os.Stdout).Encode(resp)
 					}
 
 					// Table output
-					fmt.Printf("%-24s %-18s %-15s %-10s\n", "NAME", "MAC", "IP", "TYPE")
-					fmt.Println(strings.Repeat("-", 70))
-					for _, client := range resp.Data {
-						fmt.Printf("%-24s %-18s %-15s %-10s\n",
-							truncateString(client.Name, 23),
-							client.MACAddress,
-							client.IPAddress,
-							client.Type,
+					if groupBy == "network" {
+						networks, err := client.ListNetworks(ctx, c.String("site"))
+						if err != nil {
+							return fmt.Errorf("failed to list networks: %w", err)
+						}
+
+						names := make(map[string]string, len(networks))
+						for _, network := range networks {
+							names[network.ID] = network.Name
+						}
+
+						for i, group := range groupClientsByNetwork(resp.Data, names) {
+							if i > 0 {
+								fmt.Println()
+							}
+							fmt.Printf("%s (%d)\n", group.Name, len(group.Clients))
+							printClientsTable(group.Clients)
+						}
+					} else {
+						printClientsTable(resp.Data)
+					}
+
+					fmt.Printf("\n%s\n", formatPageSummary(resp.Count, resp.TotalCount, resp.Offset, "clients"))
+					return nil
+				},
+			},
+			{
+				Name:  "get",
+				Usage: "Get network client details",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "id",
+						Usage: "Client ID",
+					},
+					&cli.StringFlag{
+						Name:  "ip",
+						Usage: "Look up the client by IPv4 or IPv6 address instead of ID",
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if (c.String("id") == "") == (c.String("ip") == "") {
+						return fmt.Errorf("exactly one of --id or --ip is required")
+					}
+
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					var networkClient *unifi.NetworkClient
+					if c.String("ip") != "" {
+						networkClient, err = client.GetNetworkClientByIP(ctx, c.String("site"), c.String("ip"))
+					} else {
+						networkClient, err = client.GetNetworkClient(ctx, c.String("site"), c.String("id"))
+					}
+					if err != nil {
+						return fmt.Errorf("failed to get network client: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(networkClient)
+					}
+
+					fmt.Printf("%-14s %s\n", "ID:", networkClient.ID)
+					fmt.Printf("%-14s %s\n", "Name:", networkClient.Name)
+					fmt.Printf("%-14s %s\n", "MAC:", networkClient.MACAddress)
+					fmt.Printf("%-14s %s\n", "IP:", networkClient.IPAddress)
+					for _, addr := range networkClient.IPv6Addresses {
+						fmt.Printf("%-14s %s\n", "IPv6:", addr)
+					}
+					fmt.Printf("%-14s %s\n", "Type:", networkClient.Type)
+					fmt.Printf("%-14s %s\n", "Connected At:", networkClient.ConnectedAt)
+					fmt.Printf("%-14s %s\n", "Uplink:", networkClient.UplinkDeviceID)
+					return nil
+				},
+			},
+			{
+				Name:  "rename",
+				Usage: "Assign a friendly name to a client",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Client ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "New client name",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					updated, err := client.SetClientName(ctx, c.String("site"), c.String("id"), c.String("name"))
+					if err != nil {
+						return fmt.Errorf("failed to rename client: %w", err)
+					}
+
+					fmt.Printf("Successfully renamed client %s to %s\n", updated.ID, updated.Name)
+					return nil
+				},
+			},
+			{
+				Name:  "set-fixed-ip",
+				Usage: "Assign a fixed IP address to a client",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Client ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "ip",
+						Usage:    "Fixed IP address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					err = client.SetClientFixedIP(ctx, c.String("site"), c.String("id"), c.String("ip"))
+					if err != nil {
+						return fmt.Errorf("failed to set fixed IP: %w", err)
+					}
+
+					fmt.Printf("Successfully set fixed IP %s on client %s\n", c.String("ip"), c.String("id"))
+					return nil
+				},
+			},
+			{
+				Name:  "bandwidth-profiles",
+				Usage: "List the user groups (bandwidth profiles) clients can be assigned to",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					groups, err := client.ListUserGroups(ctx, c.String("site"))
+					if err != nil {
+						return fmt.Errorf("failed to list bandwidth profiles: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(groups)
+					}
+
+					fmt.Printf("%-24s %-24s %-14s %-14s\n", "ID", "NAME", "DOWN", "UP")
+					fmt.Println(strings.Repeat("-", 79))
+					for _, group := range groups {
+						fmt.Printf("%-24s %-24s %-14s %-14s\n",
+							truncateString(group.ID, 23),
+							truncateString(group.Name, 23),
+							formatKbps(group.DownRateKbps),
+							formatKbps(group.UpRateKbps),
+						)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "set-bandwidth-profile",
+				Usage: "Assign a client to a user group (bandwidth profile)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Client ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "profile",
+						Usage:    "User group ID (see bandwidth-profiles)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					err = client.SetClientBandwidthProfile(ctx, c.String("site"), c.String("id"), c.String("profile"))
+					if err != nil {
+						return fmt.Errorf("failed to set bandwidth profile: %w", err)
+					}
+
+					fmt.Printf("Successfully assigned client %s to bandwidth profile %s\n", c.String("id"), c.String("profile"))
+					return nil
+				},
+			},
+			{
+				Name:  "clear-fixed-ip",
+				Usage: "Remove a client's fixed IP assignment",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Client ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					err = client.ClearClientFixedIP(ctx, c.String("site"), c.String("id"))
+					if err != nil {
+						return fmt.Errorf("failed to clear fixed IP: %w", err)
+					}
+
+					fmt.Printf("Successfully cleared fixed IP on client %s\n", c.String("id"))
+					return nil
+				},
+			},
+			{
+				Name:  "block",
+				Usage: "Block clients by MAC address",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "macs",
+						Usage:    "Comma-separated MAC addresses to block",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					macs := c.StringSlice("macs")
+					if err := client.BlockClients(ctx, c.String("site"), macs); err != nil {
+						return err
+					}
+
+					fmt.Printf("Successfully blocked %d client(s)\n", len(macs))
+					return nil
+				},
+			},
+			{
+				Name:  "unblock",
+				Usage: "Unblock clients by MAC address",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "macs",
+						Usage:    "Comma-separated MAC addresses to unblock",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					macs := c.StringSlice("macs")
+					if err := client.UnblockClients(ctx, c.String("site"), macs); err != nil {
+						return err
+					}
+
+					fmt.Printf("Successfully unblocked %d client(s)\n", len(macs))
+					return nil
+				},
+			},
+			{
+				Name:  "watch",
+				Usage: "Print clients as they connect and disconnect, until interrupted",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.DurationFlag{
+						Name:  "interval",
+						Usage: "Polling interval",
+						Value: 10 * time.Second,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					// The root context is cancelled on Ctrl-C, which ends the watch
+					ctx := c.Context
+					err = client.WatchNetworkClients(ctx, c.String("site"), c.Duration("interval"), func(ev unifi.ClientEvent) {
+						fmt.Println(formatClientEvent(ev))
+					})
+					if err != nil && ctx.Err() == nil {
+						return err
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "weak",
+				Usage: "List wireless clients with a signal-to-noise ratio below a threshold",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "min-snr",
+						Usage: "Minimum acceptable SNR in dB",
+						Value: 20,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					weak, err := client.WeakClients(ctx, c.String("site"), c.Int("min-snr"))
+					if err != nil {
+						return err
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(weak)
+					}
+
+					fmt.Printf("%-24s %-18s %-5s %-7s %-20s\n", "NAME", "MAC", "SNR", "SIGNAL", "SSID")
+					fmt.Println(strings.Repeat("-", 78))
+					for _, wc := range weak {
+						fmt.Printf("%-24s %-18s %-5d %-7d %-20s\n",
+							truncateString(wc.Name, 23),
+							wc.MACAddress,
+							wc.SNR,
+							wc.SignalStrength,
+							truncateString(wc.SSID, 20),
 						)
 					}
 
-					fmt.Printf("\nShowing %d of %d clients (offset: %d)\n",
-						resp.Count, resp.TotalCount, resp.Offset)
+					fmt.Printf("\n%d client(s) below %d dB SNR\n", len(weak), c.Int("min-snr"))
+					return nil
+				},
+			},
+			{
+				Name:  "ssid-counts",
+				Usage: "Show how many wireless clients are connected to each SSID",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					counts, err := client.SSIDClientCounts(ctx, c.String("site"))
+					if err != nil {
+						return err
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(counts)
+					}
+
+					fmt.Printf("%-32s %s\n", "SSID", "CLIENTS")
+					fmt.Println(strings.Repeat("-", 40))
+					total := 0
+					for _, sc := range sortSSIDCounts(counts) {
+						fmt.Printf("%-32s %d\n", truncateString(sc.SSID, 32), sc.Count)
+						total += sc.Count
+					}
+
+					fmt.Printf("\n%d wireless client(s) on %d SSID(s)\n", total, len(counts))
 					return nil
 				},
 			},
@@ -85,9 +574,123 @@ func clientsCommand() *cli.Command {
 	}
 }
 
+// formatClientEvent renders a presence event as a single timestamped line
+func formatClientEvent(ev unifi.ClientEvent) string {
+	symbol := "+"
+	if ev.Type == unifi.ClientDisconnected {
+		symbol = "-"
+	}
+
+	name := ev.Client.Name
+	if name == "" {
+		name = ev.Client.MACAddress
+	}
+
+	return fmt.Sprintf("%s  %s %-12s %-24s %-18s %s",
+		ev.Time.Format(time.TimeOnly),
+		symbol,
+		ev.Type,
+		truncateString(name, 23),
+		ev.Client.MACAddress,
+		ev.Client.IPAddress,
+	)
+}
+
+// printClientsTable prints clients as a NAME/MAC/IP/TYPE table
+func printClientsTable(clients []unifi.NetworkClient) {
+	fmt.Printf("%-24s %-18s %-15s %-10s\n", "NAME", "MAC", "IP", "TYPE")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, client := range clients {
+		fmt.Printf("%-24s %-18s %-15s %-10s\n",
+			truncateString(client.Name, 23),
+			client.MACAddress,
+			client.IPAddress,
+			client.Type,
+		)
+	}
+}
+
+// unknownNetwork is the group name for clients whose network cannot be resolved
+const unknownNetwork = "(unknown network)"
+
+// clientGroup is a set of clients sharing a network
+type clientGroup struct {
+	Name    string
+	Clients []unifi.NetworkClient
+}
+
+// groupClientsByNetwork groups clients under their network name, resolving
+// NetworkID through networks (ID to name) and falling back to the name the
+// client reports. Groups are sorted by name, with unresolved clients last;
+// clients keep their original order within a group.
+func groupClientsByNetwork(clients []unifi.NetworkClient, networks map[string]string) []clientGroup {
+	index := make(map[string]int)
+	var groups []clientGroup
+	for _, client := range clients {
+		name := networks[client.NetworkID]
+		if name == "" {
+			name = client.NetworkName
+		}
+		if name == "" {
+			name = client.Network
+		}
+		if name == "" {
+			name = unknownNetwork
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, clientGroup{Name: name})
+		}
+		groups[i].Clients = append(groups[i].Clients, client)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Name == unknownNetwork) != (groups[j].Name == unknownNetwork) {
+			return groups[j].Name == unknownNetwork
+		}
+		return groups[i].Name < groups[j].Name
+	})
+
+	return groups
+}
+
 func truncateString(str string, length int) string {
 	if len(str) <= length {
 		return str
 	}
 	return str[:length-3] + "..."
 }
+
+// ssidCount is the number of clients connected to one SSID
+type ssidCount struct {
+	SSID  string
+	Count int
+}
+
+// sortSSIDCounts orders per-SSID counts busiest first, breaking ties by name
+func sortSSIDCounts(counts map[string]int) []ssidCount {
+	sorted := make([]ssidCount, 0, len(counts))
+	for ssid, count := range counts {
+		sorted = append(sorted, ssidCount{SSID: ssid, Count: count})
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].SSID < sorted[j].SSID
+	})
+
+	return sorted
+}
+
+// formatKbps renders a bandwidth limit, treating zero or negative as unlimited
+func formatKbps(kbps int) string {
+	if kbps <= 0 {
+		return "Unlimited"
+	}
+	return fmt.Sprintf("%d Kbps", kbps)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+func TestGroupClientsByNetwork(t *testing.T) {
+	networks := map[string]string{
+		"net1": "Default",
+		"net2": "IoT",
+	}
+
+	clients := []unifi.NetworkClient{
+		{ID: "a", NetworkID: "net2"},
+		{ID: "b", NetworkID: "net1"},
+		{ID: "c"},
+		{ID: "d", NetworkID: "net2"},
+		{ID: "e", NetworkID: "gone", NetworkName: "Guest"},
+		{ID: "f", Network: "Cameras"},
+	}
+
+	groups := groupClientsByNetwork(clients, networks)
+
+	want := []struct {
+		name string
+		ids  []string
+	}{
+		{"Cameras", []string{"f"}},
+		{"Default", []string{"b"}},
+		{"Guest", []string{"e"}},
+		{"IoT", []string{"a", "d"}},
+		{unknownNetwork, []string{"c"}},
+	}
+
+	if len(groups) != len(want) {
+		t.Fatalf("expected %d groups, got %d", len(want), len(groups))
+	}
+	for i, w := range want {
+		if groups[i].Name != w.name {
+			t.Errorf("group %d: expected name %q, got %q", i, w.name, groups[i].Name)
+			continue
+		}
+		if len(groups[i].Clients) != len(w.ids) {
+			t.Errorf("group %q: expected %d clients, got %d", w.name, len(w.ids), len(groups[i].Clients))
+			continue
+		}
+		for j, id := range w.ids {
+			if groups[i].Clients[j].ID != id {
+				t.Errorf("group %q client %d: expected ID %s, got %s", w.name, j, id, groups[i].Clients[j].ID)
+			}
+		}
+	}
+
+	t.Run("no clients", func(t *testing.T) {
+		if groups := groupClientsByNetwork(nil, networks); len(groups) != 0 {
+			t.Errorf("expected no groups, got %d", len(groups))
+		}
+	})
+}
+
+func TestFormatClientEvent(t *testing.T) {
+	at := time.Date(2024, 1, 1, 13, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		ev   unifi.ClientEvent
+		want string
+	}{
+		{
+			name: "connected",
+			ev: unifi.ClientEvent{
+				Type:   unifi.ClientConnected,
+				Client: unifi.NetworkClient{Name: "Laptop", MACAddress: "00:11:22:33:44:55", IPAddress: "192.168.1.10"},
+				Time:   at,
+			},
+			want: "13:04:05  + connected    Laptop                   00:11:22:33:44:55  192.168.1.10",
+		},
+		{
+			name: "disconnected without name",
+			ev: unifi.ClientEvent{
+				Type:   unifi.ClientDisconnected,
+				Client: unifi.NetworkClient{MACAddress: "00:11:22:33:44:66"},
+				Time:   at,
+			},
+			want: "13:04:05  - disconnected 00:11:22:33:44:66        00:11:22:33:44:66  ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatClientEvent(tt.ev); got != tt.want {
+				t.Errorf("formatClientEvent() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortSSIDCounts(t *testing.T) {
+	got := sortSSIDCounts(map[string]int{"IoT": 2, "Guest": 5, "Home": 2, "Lab": 1})
+	want := []ssidCount{
+		{"Guest", 5},
+		{"Home", 2},
+		{"IoT", 2},
+		{"Lab", 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	t.Run("no SSIDs", func(t *testing.T) {
+		if got := sortSSIDCounts(nil); len(got) != 0 {
+			t.Errorf("expected no counts, got %v", got)
+		}
+	})
+}
+
+func TestFormatKbps(t *testing.T) {
+	for kbps, want := range map[int]string{-1: "Unlimited", 0: "Unlimited", 2000: "2000 Kbps"} {
+		if got := formatKbps(kbps); got != want {
+			t.Errorf("formatKbps(%d) = %q, want %q", kbps, got, want)
+		}
+	}
+}
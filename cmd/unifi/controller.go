@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+func controllerCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "controller",
+		Usage: "Manage the Network application itself",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "restart",
+				Usage: "Restart the Network application; the API is unavailable until it is back up",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "yes",
+						Usage: "Confirm the restart",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if !c.Bool("yes") {
+						return fmt.Errorf("restarting the controller interrupts every site it manages; pass --yes to confirm")
+					}
+
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if err := client.RestartController(ctx); err != nil {
+						return err
+					}
+
+					fmt.Println("Controller restart requested")
+					return nil
+				},
+			},
+		},
+	}
+}
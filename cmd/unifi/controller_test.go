@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestControllerRestart(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	run := func(args ...string) error {
+		app := &cli.App{
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "url"},
+				&cli.StringFlag{Name: "api-key"},
+				&cli.BoolFlag{Name: "insecure"},
+			},
+			Commands: []*cli.Command{controllerCommand()},
+		}
+		return app.Run(append([]string{"unifi", "--url", server.URL, "--api-key", "test-api-key", "controller", "restart"}, args...))
+	}
+
+	t.Run("requires confirmation", func(t *testing.T) {
+		requests = nil
+
+		err := run()
+		if err == nil || !strings.Contains(err.Error(), "--yes") {
+			t.Fatalf("expected an error asking for --yes, got %v", err)
+		}
+		if len(requests) != 0 {
+			t.Errorf("expected no requests without confirmation, got %d", len(requests))
+		}
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		requests = nil
+
+		if err := run("--yes"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(requests) != 1 {
+			t.Fatalf("expected 1 request, got %d", len(requests))
+		}
+		if got := requests[0]; got.Method != http.MethodPost || got.URL.Path != "/proxy/network/integration/v1/system" {
+			t.Errorf("expected POST /proxy/network/integration/v1/system, got %s %s", got.Method, got.URL.Path)
+		}
+	})
+}
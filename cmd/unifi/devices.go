@@ -1,11 +1,12 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/klauern/unifi-network-go"
 	"github.com/urfave/cli/v2"
@@ -29,7 +30,7 @@ func devicesCommand() *cli.Command {
 					},
 					&cli.IntFlag{
 						Name:  "limit",
-						Usage: "Maximum number of devices to return",
+						Usage: "Maximum number of devices to return (0-200, -1 for max)",
 						Value: 25,
 					},
 					&cli.StringFlag{
@@ -41,6 +42,12 @@ func devicesCommand() *cli.Command {
 						Usage: "Output in JSON format",
 						Value: false,
 					},
+					formatFlag(),
+					&cli.BoolFlag{
+						Name:  "with-meta",
+						Usage: "Include pagination metadata in JSON output",
+						Value: false,
+					},
 				},
 				Action: func(c *cli.Context) error {
 					client, err := createClient(c)
@@ -48,32 +55,42 @@ func devicesCommand() *cli.Command {
 						return err
 					}
 
+					format, err := outputFormat(c)
+					if err != nil {
+						return err
+					}
+
+					if err := rejectNDJSONFlags(c, format, "limit", "with-meta"); err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if format == formatNDJSON {
+						return writeNDJSON(os.Stdout, client.IterDevices(ctx, c.String("site"), &unifi.ListDevicesParams{
+							Type: c.String("type"),
+						}))
+					}
+
 					params := &unifi.ListDevicesParams{
 						Limit: c.Int("limit"),
 						Type:  c.String("type"),
 					}
 
-					ctx := context.Background()
 					resp, err := client.ListDevices(ctx, c.String("site"), params)
 					if err != nil {
 						return fmt.Errorf("failed to list devices: %w", err)
 					}
 
-					if c.Bool("json") {
-						return json.NewEncoder(os.Stdout).Encode(resp.Data)
+					if format == formatJSON {
+						return writeDevicesJSON(os.Stdout, resp, c.Bool("with-meta"))
 					}
 
 					// Table output
 					fmt.Printf("%-24s %-18s %-15s %-12s %-8s\n", "NAME", "MAC", "IP", "MODEL", "STATUS")
 					fmt.Println(strings.Repeat("-", 80))
 					for _, device := range resp.Data {
-						status := "Offline"
-						if device.State == 1 {
-							status = "Online"
-						}
-						if device.Disabled {
-							status = "Disabled"
-						}
+						status := device.Status()
+						status = strings.ToUpper(status[:1]) + status[1:]
 
 						fmt.Printf("%-24s %-18s %-15s %-12s %-8s\n",
 							truncateString(device.Name, 23),
@@ -87,6 +104,356 @@ func devicesCommand() *cli.Command {
 					return nil
 				},
 			},
+			{
+				Name:  "compare",
+				Usage: "Compare the device models deployed at two sites",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "site",
+						Aliases:  []string{"s"},
+						Usage:    "Site ID; give exactly two",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					sites := c.StringSlice("site")
+					if len(sites) != 2 {
+						return fmt.Errorf("exactly two --site values are required, got %d", len(sites))
+					}
+
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					devicesA, err := client.ListAllDevices(ctx, sites[0])
+					if err != nil {
+						return fmt.Errorf("failed to list devices for site %s: %w", sites[0], err)
+					}
+					devicesB, err := client.ListAllDevices(ctx, sites[1])
+					if err != nil {
+						return fmt.Errorf("failed to list devices for site %s: %w", sites[1], err)
+					}
+
+					cmp := compareModels(devicesA, devicesB)
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(cmp)
+					}
+
+					printModelComparison(os.Stdout, sites[0], sites[1], cmp)
+					return nil
+				},
+			},
+			{
+				Name:  "models",
+				Usage: "List device models supported by the controller",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "type",
+						Usage: "Only show models of this device type",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					models, err := client.ListDeviceModels(ctx)
+					if err != nil {
+						return fmt.Errorf("failed to list device models: %w", err)
+					}
+
+					if deviceType := c.String("type"); deviceType != "" {
+						filtered := models[:0]
+						for _, model := range models {
+							if model.Type == deviceType {
+								filtered = append(filtered, model)
+							}
+						}
+						models = filtered
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(models)
+					}
+
+					// Table output
+					fmt.Printf("%-14s %-24s %-6s %s\n", "MODEL", "NAME", "TYPE", "CAPABILITIES")
+					fmt.Println(strings.Repeat("-", 80))
+					for _, model := range models {
+						fmt.Printf("%-14s %-24s %-6s %s\n",
+							model.Code,
+							truncateString(model.Name, 23),
+							model.Type,
+							strings.Join(model.Capabilities, ", "),
+						)
+					}
+
+					fmt.Printf("\nTotal: %d model(s)\n", len(models))
+					return nil
+				},
+			},
+			{
+				Name:  "outdated",
+				Usage: "List devices with a firmware upgrade available",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					devices, err := client.OutdatedDevices(ctx, c.String("site"))
+					if err != nil {
+						return fmt.Errorf("failed to list outdated devices: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(devices)
+					}
+
+					// Table output
+					fmt.Printf("%-24s %-18s %-12s %-12s\n", "NAME", "MAC", "MODEL", "VERSION")
+					fmt.Println(strings.Repeat("-", 70))
+					for _, device := range devices {
+						fmt.Printf("%-24s %-18s %-12s %-12s\n",
+							truncateString(device.Name, 23),
+							device.MAC,
+							device.Model,
+							device.Version,
+						)
+					}
+
+					fmt.Printf("\n%d device(s) need a firmware upgrade\n", len(devices))
+					return nil
+				},
+			},
+			{
+				Name:  "stale",
+				Usage: "List devices the controller has not heard from recently",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.DurationFlag{
+						Name:  "older-than",
+						Usage: "Report devices last seen longer ago than this",
+						Value: time.Hour,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					devices, err := client.StaleDevices(ctx, c.String("site"), c.Duration("older-than"))
+					if err != nil {
+						return err
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(devices)
+					}
+
+					// Table output
+					now := time.Now()
+					fmt.Printf("%-24s %-18s %-12s %-20s\n", "NAME", "MAC", "MODEL", "LAST SEEN")
+					fmt.Println(strings.Repeat("-", 77))
+					for _, device := range devices {
+						fmt.Printf("%-24s %-18s %-12s %-20s\n",
+							truncateString(device.Name, 23),
+							device.MAC,
+							device.Model,
+							formatLastSeen(device.LastSeenTime(), now),
+						)
+					}
+
+					fmt.Printf("\n%d device(s) not seen in the last %s\n", len(devices), c.Duration("older-than"))
+					return nil
+				},
+			},
+			{
+				Name:  "overheating",
+				Usage: "List devices reporting a temperature above a threshold",
+				Flags: []cli.Flag{
+					&cli.Float64Flag{
+						Name:  "max-temp",
+						Usage: "Maximum acceptable temperature in °C",
+						Value: 75,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					stats, err := client.OverheatingDevices(ctx, c.String("site"), c.Float64("max-temp"))
+					if err != nil {
+						return err
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(stats)
+					}
+
+					// Table output
+					fmt.Printf("%-26s %-18s %-8s %-6s\n", "ID", "MAC", "TEMP", "FAN")
+					fmt.Println(strings.Repeat("-", 62))
+					for _, s := range stats {
+						fmt.Printf("%-26s %-18s %-8s %-6d\n",
+							truncateString(s.ID, 25),
+							s.MAC,
+							fmt.Sprintf("%.1f°C", s.SystemStats.Temperature),
+							s.SystemStats.FanLevel,
+						)
+					}
+
+					fmt.Printf("\n%d device(s) above %.1f°C\n", len(stats), c.Float64("max-temp"))
+					return nil
+				},
+			},
+			{
+				Name:  "update-check",
+				Usage: "Summarize firmware updates available across the site, by model",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					summary, err := client.CheckFirmwareUpdates(ctx, c.String("site"))
+					if err != nil {
+						return fmt.Errorf("failed to check firmware updates: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(summary)
+					}
+
+					// Table output
+					fmt.Printf("%-16s %-6s %-9s %-20s %-20s\n", "MODEL", "TOTAL", "OUTDATED", "CURRENT", "AVAILABLE")
+					fmt.Println(strings.Repeat("-", 75))
+					for _, model := range summary.Models {
+						fmt.Printf("%-16s %-6d %-9d %-20s %-20s\n",
+							truncateString(model.Model, 15),
+							model.Total,
+							model.UpdatesAvailable,
+							truncateString(strings.Join(model.CurrentVersions, ","), 19),
+							truncateString(strings.Join(model.AvailableVersions, ","), 19),
+						)
+					}
+
+					fmt.Printf("\n%d of %d device(s) have a firmware update available\n",
+						summary.UpdatesAvailable, summary.TotalDevices)
+					return nil
+				},
+			},
+			{
+				Name:  "find",
+				Usage: "Find a device by MAC address across all sites",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "mac",
+						Usage:    "Device MAC address",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					siteID, device, err := client.FindDeviceByMAC(ctx, c.String("mac"))
+					if err != nil {
+						return fmt.Errorf("failed to find device: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(struct {
+							SiteID string        `json:"siteId"`
+							Device *unifi.Device `json:"device"`
+						}{siteID, device})
+					}
+
+					fmt.Printf("%-8s %s\n", "Site:", siteID)
+					fmt.Printf("%-8s %s\n", "ID:", device.ID)
+					fmt.Printf("%-8s %s\n", "Name:", device.Name)
+					fmt.Printf("%-8s %s\n", "MAC:", device.MAC)
+					fmt.Printf("%-8s %s\n", "IP:", device.IP)
+					fmt.Printf("%-8s %s\n", "Model:", device.Model)
+					return nil
+				},
+			},
 			{
 				Name:  "get",
 				Usage: "Get device details",
@@ -109,7 +476,7 @@ func devicesCommand() *cli.Command {
 						return err
 					}
 
-					ctx := context.Background()
+					ctx := c.Context
 					device, err := client.GetDevice(ctx, c.String("site"), c.String("id"))
 					if err != nil {
 						return fmt.Errorf("failed to get device: %w", err)
@@ -133,6 +500,16 @@ func devicesCommand() *cli.Command {
 						Usage:   "Site ID",
 						Value:   "default",
 					},
+					&cli.BoolFlag{
+						Name:  "watch",
+						Usage: "Continuously refresh a one-line summary until interrupted",
+						Value: false,
+					},
+					&cli.DurationFlag{
+						Name:  "interval",
+						Usage: "Refresh interval when watching",
+						Value: 5 * time.Second,
+					},
 				},
 				Action: func(c *cli.Context) error {
 					client, err := createClient(c)
@@ -140,13 +517,158 @@ func devicesCommand() *cli.Command {
 						return err
 					}
 
-					ctx := context.Background()
+					if !c.Bool("watch") {
+						ctx := c.Context
+						stats, err := client.GetDeviceStatistics(ctx, c.String("site"), c.String("id"))
+						if err != nil {
+							return fmt.Errorf("failed to get device statistics: %w", err)
+						}
+
+						return json.NewEncoder(os.Stdout).Encode(stats)
+					}
+
+					if c.Duration("interval") <= 0 {
+						return fmt.Errorf("interval must be positive")
+					}
+
+					// The root context is cancelled on Ctrl-C, which ends the watch
+					ctx := c.Context
+
+					ticker := time.NewTicker(c.Duration("interval"))
+					defer ticker.Stop()
+
+					for {
+						stats, err := client.GetDeviceStatistics(ctx, c.String("site"), c.String("id"))
+						if err != nil {
+							if ctx.Err() != nil {
+								fmt.Println()
+								return nil
+							}
+							return fmt.Errorf("failed to get device statistics: %w", err)
+						}
+						fmt.Printf("\r\033[K%s", formatStatsLine(time.Now(), stats))
+
+						select {
+						case <-ctx.Done():
+							fmt.Println()
+							return nil
+						case <-ticker.C:
+						}
+					}
+				},
+			},
+			{
+				Name:  "ports",
+				Usage: "Get per-port statistics for a device",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Device ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
 					stats, err := client.GetDeviceStatistics(ctx, c.String("site"), c.String("id"))
 					if err != nil {
 						return fmt.Errorf("failed to get device statistics: %w", err)
 					}
 
-					return json.NewEncoder(os.Stdout).Encode(stats)
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(stats.PortTable)
+					}
+
+					// Table output
+					fmt.Printf("%-5s %-16s %-14s %-14s %-10s %-10s %-8s\n", "PORT", "NAME", "RX BYTES", "TX BYTES", "RX ERR", "TX ERR", "POE (W)")
+					fmt.Println(strings.Repeat("-", 83))
+					for _, port := range stats.PortTable {
+						fmt.Printf("%-5d %-16s %-14d %-14d %-10d %-10d %-8.2f\n",
+							port.PortIDX,
+							truncateString(port.Name, 15),
+							port.RxBytes,
+							port.TxBytes,
+							port.RxErrors,
+							port.TxErrors,
+							port.PoEPower,
+						)
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:  "support-info",
+				Usage: "Download a device's support-info bundle",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Device ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.StringFlag{
+						Name:     "out",
+						Aliases:  []string{"o"},
+						Usage:    "Output file, or - for stdout",
+						Required: true,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					body, err := client.DownloadDeviceSupportInfo(ctx, c.String("site"), c.String("id"))
+					if err != nil {
+						return fmt.Errorf("failed to download support info: %w", err)
+					}
+					defer func() {
+						_ = body.Close()
+					}()
+
+					if c.String("out") == "-" {
+						_, err = io.Copy(os.Stdout, body)
+						return err
+					}
+
+					out, err := os.Create(c.String("out"))
+					if err != nil {
+						return fmt.Errorf("failed to create output file: %w", err)
+					}
+
+					n, err := io.Copy(out, body)
+					if closeErr := out.Close(); err == nil {
+						err = closeErr
+					}
+					if err != nil {
+						return fmt.Errorf("failed to write support info: %w", err)
+					}
+
+					fmt.Fprintf(os.Stderr, "Wrote %d bytes to %s\n", n, c.String("out"))
+					return nil
 				},
 			},
 			{
@@ -180,7 +702,7 @@ func devicesCommand() *cli.Command {
 						Action: c.String("action"),
 					}
 
-					ctx := context.Background()
+					ctx := c.Context
 					err = client.ExecuteDeviceAction(ctx, c.String("site"), c.String("id"), action)
 					if err != nil {
 						return fmt.Errorf("failed to execute device action: %w", err)
@@ -190,6 +712,221 @@ func devicesCommand() *cli.Command {
 					return nil
 				},
 			},
+			{
+				Name:  "adopt",
+				Usage: "Adopt a device, or every device pending adoption with --all",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "id",
+						Usage: "Device ID",
+					},
+					&cli.BoolFlag{
+						Name:  "all",
+						Usage: "Adopt every device pending adoption",
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					if (c.String("id") == "") == !c.Bool("all") {
+						return fmt.Errorf("exactly one of --id or --all is required")
+					}
+
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if !c.Bool("all") {
+						adopted, err := client.AdoptDevice(ctx, c.String("site"), c.String("id"))
+						if err != nil {
+							return err
+						}
+						if !adopted {
+							fmt.Printf("Device %s is already adopted\n", c.String("id"))
+							return nil
+						}
+						fmt.Printf("Successfully adopted device %s\n", c.String("id"))
+						return nil
+					}
+
+					adopted, err := client.AdoptAllPending(ctx, c.String("site"))
+					fmt.Printf("Adopted %d device(s)\n", adopted)
+					return err
+				},
+			},
+			{
+				Name:  "kick-client",
+				Usage: "Disconnect a wireless client from one access point; it may reconnect",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Access point device ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "mac",
+						Usage:    "Client MAC address",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if err := client.DisconnectClientFromDevice(ctx, c.String("site"), c.String("id"), c.String("mac")); err != nil {
+						return err
+					}
+
+					fmt.Printf("Successfully disconnected client %s from device %s\n", c.String("mac"), c.String("id"))
+					return nil
+				},
+			},
+			{
+				Name:  "set-mgmt-vlan",
+				Usage: "Move a device's management interface to another VLAN",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Device ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.IntFlag{
+						Name:     "vlan",
+						Usage:    "VLAN ID (0-4095, 0 for untagged)",
+						Required: true,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if err := client.SetDeviceMgmtVLAN(ctx, c.String("site"), c.String("id"), c.Int("vlan")); err != nil {
+						return err
+					}
+
+					fmt.Printf("Successfully set management VLAN of device %s to %d\n", c.String("id"), c.Int("vlan"))
+					return nil
+				},
+			},
+			{
+				Name:  "radios",
+				Usage: "Show the radio configuration of an access point",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Device ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					radios, err := client.GetDeviceRadios(ctx, c.String("site"), c.String("id"))
+					if err != nil {
+						return err
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(radios)
+					}
+
+					if len(radios) == 0 {
+						fmt.Println("Device has no radios")
+						return nil
+					}
+
+					fmt.Printf("%-8s %-8s %-8s %-8s %s\n", "RADIO", "BAND", "CHANNEL", "WIDTH", "TX POWER")
+					fmt.Println(strings.Repeat("-", 50))
+					for _, r := range radios {
+						fmt.Printf("%-8s %-8s %-8s %-8s %s\n",
+							r.Name, formatRadioBand(r.Band), formatRadioChannel(r.Channel),
+							fmt.Sprintf("%d MHz", r.Width), formatTxPower(r))
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "set-channel",
+				Usage: "Set the channel of one radio on an access point",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Device ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.StringFlag{
+						Name:     "band",
+						Usage:    "Radio band (ng for 2.4 GHz, na for 5 GHz, 6e for 6 GHz)",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:     "channel",
+						Usage:    "Channel number, 0 for auto",
+						Required: true,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					err = client.SetRadioChannel(ctx, c.String("site"), c.String("id"), c.String("band"), c.Int("channel"))
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("Successfully set %s radio of device %s to channel %s\n",
+						formatRadioBand(c.String("band")), c.String("id"), formatRadioChannel(c.Int("channel")))
+					return nil
+				},
+			},
 			{
 				Name:  "port",
 				Usage: "Execute port action (reset, enable, disable)",
@@ -232,7 +969,7 @@ func devicesCommand() *cli.Command {
 						Action:  c.String("action"),
 					}
 
-					ctx := context.Background()
+					ctx := c.Context
 					err = client.ExecutePortAction(ctx, c.String("site"), c.String("id"), action)
 					if err != nil {
 						return fmt.Errorf("failed to execute port action: %w", err)
@@ -246,3 +983,67 @@ func devicesCommand() *cli.Command {
 		},
 	}
 }
+
+// writeDevicesJSON encodes a device listing as JSON. By default only the
+// devices are written; withMeta writes the full response including pagination.
+func writeDevicesJSON(w io.Writer, resp *unifi.ListDevicesResponse, withMeta bool) error {
+	if withMeta {
+		return json.NewEncoder(w).Encode(resp)
+	}
+	return json.NewEncoder(w).Encode(resp.Data)
+}
+
+// formatStatsLine renders a one-line summary of device statistics for watch mode
+func formatStatsLine(now time.Time, stats *unifi.DeviceStatistics) string {
+	line := fmt.Sprintf("%s  cpu %5.1f%%  mem %5.1f%%  temp %5.1f°C  rx %10.0f B/s  tx %10.0f B/s",
+		now.Format(time.TimeOnly),
+		stats.CPUPercent(),
+		stats.MemoryPercent(),
+		stats.SystemStats.Temperature,
+		stats.RxRate,
+		stats.TxRate,
+	)
+	if one, five, fifteen, ok := stats.LoadAverages(); ok {
+		line += fmt.Sprintf("  load %.2f %.2f %.2f", one, five, fifteen)
+	}
+	return line
+}
+
+// formatLastSeen renders how long ago a device was last seen, to the minute
+func formatLastSeen(seen, now time.Time) string {
+	ago := now.Sub(seen).Round(time.Minute)
+	if days := int(ago.Hours()) / 24; days > 0 {
+		return fmt.Sprintf("%dd %02dh ago", days, int(ago.Hours())%24)
+	}
+	return fmt.Sprintf("%dh %02dm ago", int(ago.Hours()), int(ago.Minutes())%60)
+}
+
+// formatRadioBand renders a radio band code as a frequency
+func formatRadioBand(band string) string {
+	switch band {
+	case unifi.RadioBand2G:
+		return "2.4 GHz"
+	case unifi.RadioBand5G:
+		return "5 GHz"
+	case unifi.RadioBand6G:
+		return "6 GHz"
+	default:
+		return band
+	}
+}
+
+// formatRadioChannel renders a radio channel, where 0 means automatic
+func formatRadioChannel(channel int) string {
+	if channel == 0 {
+		return "auto"
+	}
+	return fmt.Sprintf("%d", channel)
+}
+
+// formatTxPower renders a radio's transmit power setting
+func formatTxPower(r unifi.RadioConfig) string {
+	if r.TxPowerMode == "custom" {
+		return fmt.Sprintf("%d dBm", r.TxPower)
+	}
+	return r.TxPowerMode
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+// modelComparison lists the device models found at only one of two sites
+type modelComparison struct {
+	OnlyA  []string       `json:"onlyA"`  // Models present at the first site only
+	OnlyB  []string       `json:"onlyB"`  // Models present at the second site only
+	Common []string       `json:"common"` // Models present at both sites
+	Counts map[string]int `json:"-"`      // Devices per model across both sites, for display
+}
+
+// compareModels compares the device models of two sites. It reuses
+// DiffDevices by collapsing each site to one entry per model, keyed by the
+// model in place of the device ID. Devices without a model are skipped.
+func compareModels(a, b []unifi.Device) modelComparison {
+	counts := make(map[string]int)
+	byModel := func(devices []unifi.Device) []unifi.Device {
+		seen := make(map[string]bool)
+		var models []unifi.Device
+		for _, device := range devices {
+			if device.Model == "" {
+				continue
+			}
+			counts[device.Model]++
+			if !seen[device.Model] {
+				seen[device.Model] = true
+				models = append(models, unifi.Device{ID: device.Model, Model: device.Model})
+			}
+		}
+		return models
+	}
+
+	modelsA := byModel(a)
+	modelsB := byModel(b)
+	diff := unifi.DiffDevices(modelsA, modelsB)
+
+	cmp := modelComparison{
+		OnlyA:  []string{},
+		OnlyB:  []string{},
+		Common: []string{},
+		Counts: counts,
+	}
+	for _, device := range diff.Removed {
+		cmp.OnlyA = append(cmp.OnlyA, device.Model)
+	}
+	for _, device := range diff.Added {
+		cmp.OnlyB = append(cmp.OnlyB, device.Model)
+	}
+
+	onlyB := make(map[string]bool, len(cmp.OnlyB))
+	for _, model := range cmp.OnlyB {
+		onlyB[model] = true
+	}
+	for _, device := range modelsB {
+		if !onlyB[device.Model] {
+			cmp.Common = append(cmp.Common, device.Model)
+		}
+	}
+	sort.Strings(cmp.Common)
+
+	return cmp
+}
+
+// printModelComparison writes the models unique to each site
+func printModelComparison(w io.Writer, siteA, siteB string, cmp modelComparison) {
+	section := func(title string, models []string) {
+		fmt.Fprintf(w, "%s (%d)\n", title, len(models))
+		if len(models) == 0 {
+			fmt.Fprintln(w, "  (none)")
+		}
+		for _, model := range models {
+			fmt.Fprintf(w, "  %-20s %d device(s)\n", model, cmp.Counts[model])
+		}
+	}
+
+	section(fmt.Sprintf("Only in %s", siteA), cmp.OnlyA)
+	fmt.Fprintln(w)
+	section(fmt.Sprintf("Only in %s", siteB), cmp.OnlyB)
+	fmt.Fprintf(w, "\n%d model(s) in common\n", len(cmp.Common))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+func devicesWithModels(models ...string) []unifi.Device {
+	devices := make([]unifi.Device, len(models))
+	for i, model := range models {
+		devices[i] = unifi.Device{ID: model + "-" + string(rune('a'+i)), Model: model}
+	}
+	return devices
+}
+
+func TestCompareModels(t *testing.T) {
+	a := devicesWithModels("U6-Pro", "U6-Pro", "USW-24", "UDM-Pro", "")
+	b := devicesWithModels("U6-Lite", "USW-24", "USW-24", "UDM-Pro", "U7-Pro")
+
+	cmp := compareModels(a, b)
+
+	if want := []string{"U6-Pro"}; !reflect.DeepEqual(cmp.OnlyA, want) {
+		t.Errorf("expected only in A %v, got %v", want, cmp.OnlyA)
+	}
+	if want := []string{"U6-Lite", "U7-Pro"}; !reflect.DeepEqual(cmp.OnlyB, want) {
+		t.Errorf("expected only in B %v, got %v", want, cmp.OnlyB)
+	}
+	if want := []string{"UDM-Pro", "USW-24"}; !reflect.DeepEqual(cmp.Common, want) {
+		t.Errorf("expected common %v, got %v", want, cmp.Common)
+	}
+	if cmp.Counts["U6-Pro"] != 2 || cmp.Counts["USW-24"] != 3 {
+		t.Errorf("unexpected counts: %v", cmp.Counts)
+	}
+
+	t.Run("identical sites", func(t *testing.T) {
+		cmp := compareModels(devicesWithModels("USW-24", "U6-Pro"), devicesWithModels("U6-Pro", "USW-24"))
+		if len(cmp.OnlyA) != 0 || len(cmp.OnlyB) != 0 || len(cmp.Common) != 2 {
+			t.Errorf("expected only common models, got %+v", cmp)
+		}
+	})
+
+	t.Run("empty site", func(t *testing.T) {
+		cmp := compareModels(nil, devicesWithModels("U6-Pro"))
+		if len(cmp.OnlyA) != 0 || !reflect.DeepEqual(cmp.OnlyB, []string{"U6-Pro"}) || len(cmp.Common) != 0 {
+			t.Errorf("unexpected comparison: %+v", cmp)
+		}
+	})
+
+	t.Run("printed report", func(t *testing.T) {
+		var buf bytes.Buffer
+		printModelComparison(&buf, "hq", "branch", cmp)
+		out := buf.String()
+		for _, want := range []string{"Only in hq (1)", "U6-Pro", "2 device(s)", "Only in branch (2)", "U7-Pro", "2 model(s) in common"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+}
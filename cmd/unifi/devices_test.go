@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+func TestFormatStatsLine(t *testing.T) {
+	stats := &unifi.DeviceStatistics{
+		CPU:    12.5,
+		Memory: 48.25,
+		RxRate: 1500,
+		TxRate: 250.4,
+	}
+	stats.SystemStats.Temperature = 51.3
+
+	now := time.Date(2024, 1, 1, 13, 4, 5, 0, time.UTC)
+	got := formatStatsLine(now, stats)
+	want := "13:04:05  cpu  12.5%  mem  48.2%  temp  51.3°C  rx       1500 B/s  tx        250 B/s"
+	if got != want {
+		t.Errorf("formatStatsLine() =\n%q\nwant\n%q", got, want)
+	}
+
+	stats.SystemStats.LoadAvg1 = "0.42"
+	stats.SystemStats.LoadAvg5 = "0.35"
+	stats.SystemStats.LoadAvg15 = "0.3"
+	got = formatStatsLine(now, stats)
+	if want := want + "  load 0.42 0.35 0.30"; got != want {
+		t.Errorf("formatStatsLine() with load =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestFormatLastSeen(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{90 * time.Minute, "1h 30m ago"},
+		{2*time.Hour + 29*time.Second, "2h 00m ago"},
+		{50 * time.Hour, "2d 02h ago"},
+	}
+	for _, tt := range tests {
+		if got := formatLastSeen(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("formatLastSeen(%v ago) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestWriteDevicesJSON(t *testing.T) {
+	resp := &unifi.ListDevicesResponse{
+		PaginatedResponse: unifi.PaginatedResponse{
+			Offset:     25,
+			Limit:      25,
+			Count:      1,
+			TotalCount: 26,
+		},
+		Data: []unifi.Device{{ID: "device1", Name: "Office AP"}},
+	}
+
+	t.Run("without meta", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeDevicesJSON(&buf, resp, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var devices []map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &devices); err != nil {
+			t.Fatalf("expected a JSON array of devices: %v\n%s", err, buf.String())
+		}
+		if len(devices) != 1 || devices[0]["_id"] != "device1" {
+			t.Errorf("unexpected devices: %v", devices)
+		}
+	})
+
+	t.Run("with meta", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeDevicesJSON(&buf, resp, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(buf.Bytes(), &envelope); err != nil {
+			t.Fatalf("expected a JSON object: %v\n%s", err, buf.String())
+		}
+		want := map[string]string{
+			"offset":     "25",
+			"limit":      "25",
+			"count":      "1",
+			"totalCount": "26",
+		}
+		for key, value := range want {
+			if got := string(envelope[key]); got != value {
+				t.Errorf("expected %s=%s, got %s", key, value, got)
+			}
+		}
+
+		var devices []unifi.Device
+		if err := json.Unmarshal(envelope["data"], &devices); err != nil {
+			t.Fatalf("failed to decode data: %v", err)
+		}
+		if len(devices) != 1 || devices[0].ID != "device1" {
+			t.Errorf("unexpected devices: %+v", devices)
+		}
+	})
+}
+
+func TestFormatRadio(t *testing.T) {
+	if got := formatRadioBand(unifi.RadioBand5G); got != "5 GHz" {
+		t.Errorf("formatRadioBand(na) = %q", got)
+	}
+	if got := formatRadioBand("xx"); got != "xx" {
+		t.Errorf("formatRadioBand(xx) = %q", got)
+	}
+	if got := formatRadioChannel(0); got != "auto" {
+		t.Errorf("formatRadioChannel(0) = %q", got)
+	}
+	if got := formatTxPower(unifi.RadioConfig{TxPowerMode: "custom", TxPower: 17}); got != "17 dBm" {
+		t.Errorf("formatTxPower(custom) = %q", got)
+	}
+	if got := formatTxPower(unifi.RadioConfig{TxPowerMode: "high"}); got != "high" {
+		t.Errorf("formatTxPower(high) = %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+// checkResult is the outcome of a single doctor check
+type checkResult struct {
+	Name     string
+	Passed   bool
+	Detail   string // Short description of what was found, or the failure
+	Duration time.Duration
+}
+
+// doctorCheck is a named read-only check returning a detail message on success
+type doctorCheck struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+// doctorSummary aggregates the results of a doctor run
+type doctorSummary struct {
+	Passed   int
+	Failed   int
+	Duration time.Duration
+}
+
+func doctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "Run read-only checks to diagnose connection and setup issues",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "site",
+				Aliases: []string{"s"},
+				Usage:   "Site ID used for the device check",
+				Value:   "default",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Timeout for each check",
+				Value: 10 * time.Second,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			client, err := createClient(c)
+			if err != nil {
+				return err
+			}
+
+			ctx := c.Context
+			results := runChecks(ctx, c.Duration("timeout"), doctorChecks(client, c.String("site")))
+			fmt.Print(formatDoctorReport(results))
+
+			if summary := summarizeChecks(results); summary.Failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", summary.Failed, len(results))
+			}
+			return nil
+		},
+	}
+}
+
+// doctorChecks returns the checks run by the doctor command, in order
+func doctorChecks(client *unifi.Client, siteID string) []doctorCheck {
+	return []doctorCheck{
+		{
+			name: "Controller reachable",
+			run: func(ctx context.Context) (string, error) {
+				_, err := client.GetApplicationInfo(ctx)
+				// Any API error means the controller answered
+				var apiErr *unifi.Error
+				if err != nil && !errors.As(err, &apiErr) {
+					return "", err
+				}
+				return "controller responded", nil
+			},
+		},
+		{
+			name: "API key accepted",
+			run: func(ctx context.Context) (string, error) {
+				info, err := client.GetApplicationInfo(ctx)
+				if err != nil {
+					return "", err
+				}
+				return "UniFi Network " + info.ApplicationVersion, nil
+			},
+		},
+		{
+			name: "List sites",
+			run: func(ctx context.Context) (string, error) {
+				resp, err := client.ListSites(ctx, nil)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d site(s)", resp.TotalCount), nil
+			},
+		},
+		{
+			name: fmt.Sprintf("List devices (site %s)", siteID),
+			run: func(ctx context.Context) (string, error) {
+				resp, err := client.ListDevices(ctx, siteID, nil)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d device(s)", resp.TotalCount), nil
+			},
+		},
+	}
+}
+
+// runChecks runs each check in order with its own timeout and times it
+func runChecks(ctx context.Context, timeout time.Duration, checks []doctorCheck) []checkResult {
+	results := make([]checkResult, 0, len(checks))
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		detail, err := check.run(checkCtx)
+		elapsed := time.Since(start)
+		cancel()
+
+		result := checkResult{Name: check.name, Passed: err == nil, Detail: detail, Duration: elapsed}
+		if err != nil {
+			result.Detail = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// summarizeChecks counts passed and failed checks and their total duration
+func summarizeChecks(results []checkResult) doctorSummary {
+	var summary doctorSummary
+	for _, result := range results {
+		if result.Passed {
+			summary.Passed++
+		} else {
+			summary.Failed++
+		}
+		summary.Duration += result.Duration
+	}
+	return summary
+}
+
+// formatDoctorReport renders check results as a pass/fail table with a summary line
+func formatDoctorReport(results []checkResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-6s %-32s %-10s %s\n", "STATUS", "CHECK", "TIME", "DETAIL")
+	b.WriteString(strings.Repeat("-", 80) + "\n")
+	for _, result := range results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "%-6s %-32s %-10s %s\n",
+			status,
+			truncateString(result.Name, 31),
+			result.Duration.Round(time.Millisecond),
+			result.Detail,
+		)
+	}
+
+	summary := summarizeChecks(results)
+	fmt.Fprintf(&b, "\n%d passed, %d failed in %s\n",
+		summary.Passed, summary.Failed, summary.Duration.Round(time.Millisecond))
+	return b.String()
+}
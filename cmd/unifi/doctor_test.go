@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarizeChecks(t *testing.T) {
+	results := []checkResult{
+		{Name: "Controller reachable", Passed: true, Duration: 40 * time.Millisecond},
+		{Name: "API key accepted", Passed: false, Detail: "Unauthorized", Duration: 35 * time.Millisecond},
+		{Name: "List sites", Passed: true, Duration: 25 * time.Millisecond},
+	}
+
+	got := summarizeChecks(results)
+	want := doctorSummary{Passed: 2, Failed: 1, Duration: 100 * time.Millisecond}
+	if got != want {
+		t.Errorf("summarizeChecks() = %+v, want %+v", got, want)
+	}
+
+	if got := summarizeChecks(nil); got != (doctorSummary{}) {
+		t.Errorf("summarizeChecks(nil) = %+v, want zero", got)
+	}
+}
+
+func TestFormatDoctorReport(t *testing.T) {
+	results := []checkResult{
+		{Name: "Controller reachable", Passed: true, Detail: "controller responded", Duration: 12 * time.Millisecond},
+		{Name: "API key accepted", Passed: false, Detail: "Unauthorized", Duration: 8 * time.Millisecond},
+	}
+
+	report := formatDoctorReport(results)
+	lines := strings.Split(strings.TrimSpace(report), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 report lines, got %d:\n%s", len(lines), report)
+	}
+	if !strings.HasPrefix(lines[2], "PASS   Controller reachable") || !strings.Contains(lines[2], "12ms") {
+		t.Errorf("unexpected pass line: %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[3], "FAIL   API key accepted") || !strings.HasSuffix(lines[3], "Unauthorized") {
+		t.Errorf("unexpected fail line: %q", lines[3])
+	}
+	if lines[5] != "1 passed, 1 failed in 20ms" {
+		t.Errorf("unexpected summary line: %q", lines[5])
+	}
+}
+
+func TestRunChecks(t *testing.T) {
+	checks := []doctorCheck{
+		{name: "ok", run: func(ctx context.Context) (string, error) { return "fine", nil }},
+		{name: "broken", run: func(ctx context.Context) (string, error) { return "", errors.New("connection refused") }},
+		{name: "slow", run: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+	}
+
+	results := runChecks(context.Background(), 10*time.Millisecond, checks)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	if !results[0].Passed || results[0].Detail != "fine" {
+		t.Errorf("unexpected result for ok check: %+v", results[0])
+	}
+	if results[1].Passed || results[1].Detail != "connection refused" {
+		t.Errorf("unexpected result for broken check: %+v", results[1])
+	}
+	if results[2].Passed || !strings.Contains(results[2].Detail, "deadline exceeded") {
+		t.Errorf("expected slow check to time out, got %+v", results[2])
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+func eventsCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "events",
+		Aliases: []string{"e"},
+		Usage:   "View UniFi device events and alarms",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List recent device events",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.DurationFlag{
+						Name:  "since",
+						Usage: "Only show events newer than this duration (e.g. 1h, 30m)",
+					},
+					&cli.StringFlag{
+						Name:  "severity",
+						Usage: "Filter by severity (info, warning, critical)",
+					},
+					&cli.IntFlag{
+						Name:  "limit",
+						Usage: "Maximum number of events to return (0-200, -1 for max)",
+						Value: 25,
+					},
+					&cli.IntFlag{
+						Name:  "offset",
+						Usage: "Starting offset for pagination",
+						Value: 0,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					params := &unifi.EventParams{
+						Limit:    c.Int("limit"),
+						Offset:   c.Int("offset"),
+						Severity: c.String("severity"),
+					}
+					if since := c.Duration("since"); since > 0 {
+						params.Start = time.Now().Add(-since)
+					}
+
+					ctx := c.Context
+					resp, err := client.ListDeviceEvents(ctx, c.String("site"), params)
+					if err != nil {
+						return fmt.Errorf("failed to list events: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(resp)
+					}
+
+					// Table output
+					fmt.Printf("%-20s %-10s %-24s %-30s\n", "TIME", "SEVERITY", "DEVICE", "MESSAGE")
+					fmt.Println(strings.Repeat("-", 90))
+					for _, event := range resp.Data {
+						fmt.Printf("%-20s %-10s %-24s %-30s\n",
+							event.Timestamp().Format(time.DateTime),
+							event.Severity,
+							truncateString(event.DeviceName, 23),
+							event.Message,
+						)
+					}
+
+					fmt.Printf("\n%s\n", formatPageSummary(resp.Count, resp.TotalCount, resp.Offset, "events"))
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+func guestControlCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "guest",
+		Usage: "Manage the guest portal and guest network policy",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "get",
+				Usage: "Show guest control settings",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					settings, err := client.GetGuestControl(ctx, c.String("site"))
+					if err != nil {
+						return err
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(settings)
+					}
+
+					printGuestControl(settings)
+					return nil
+				},
+			},
+			{
+				Name:  "set",
+				Usage: "Change guest control settings; unset flags keep their current value",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "portal",
+						Usage: "Enable the guest portal",
+					},
+					&cli.StringFlag{
+						Name:  "auth",
+						Usage: "Portal authentication (none, hotspot, password, external)",
+					},
+					&cli.IntFlag{
+						Name:  "expire",
+						Usage: "Authorization expiry in minutes",
+					},
+					&cli.StringFlag{
+						Name:  "redirect-url",
+						Usage: "Redirect guests to this URL after authorizing; empty disables the redirect",
+					},
+					&cli.BoolFlag{
+						Name:  "isolation",
+						Usage: "Isolate guests from each other",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					settings, err := client.GetGuestControl(ctx, c.String("site"))
+					if err != nil {
+						return err
+					}
+
+					if c.IsSet("portal") {
+						settings.PortalEnabled = c.Bool("portal")
+					}
+					if c.IsSet("auth") {
+						settings.AuthType = c.String("auth")
+					}
+					if c.IsSet("expire") {
+						settings.ExpireMinutes = c.Int("expire")
+					}
+					if c.IsSet("redirect-url") {
+						settings.RedirectURL = c.String("redirect-url")
+						settings.RedirectEnabled = settings.RedirectURL != ""
+					}
+					if c.IsSet("isolation") {
+						settings.Isolation = c.Bool("isolation")
+					}
+
+					updated, err := client.UpdateGuestControl(ctx, c.String("site"), *settings)
+					if err != nil {
+						return err
+					}
+
+					printGuestControl(updated)
+					return nil
+				},
+			},
+			guestPortalCommand(),
+		},
+	}
+}
+
+func guestPortalCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "portal",
+		Usage: "Manage the guest portal page text and theme",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "get",
+				Usage: "Show portal settings",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					settings, err := client.GetPortalSettings(ctx, c.String("site"))
+					if err != nil {
+						return err
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(settings)
+					}
+
+					printPortalSettings(settings)
+					return nil
+				},
+			},
+			{
+				Name:  "set",
+				Usage: "Change portal settings; unset flags keep their current value",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.StringFlag{
+						Name:  "title",
+						Usage: "Portal page title",
+					},
+					&cli.StringFlag{
+						Name:  "welcome",
+						Usage: "Welcome text shown above the login form",
+					},
+					&cli.StringFlag{
+						Name:  "terms",
+						Usage: "Terms of service text; empty disables the terms",
+					},
+					&cli.StringFlag{
+						Name:  "bg-color",
+						Usage: "Background color (#rrggbb)",
+					},
+					&cli.StringFlag{
+						Name:  "text-color",
+						Usage: "Text color (#rrggbb)",
+					},
+					&cli.StringFlag{
+						Name:  "link-color",
+						Usage: "Link color (#rrggbb)",
+					},
+					&cli.StringFlag{
+						Name:  "box-color",
+						Usage: "Login box color (#rrggbb)",
+					},
+					&cli.StringFlag{
+						Name:  "button-color",
+						Usage: "Button color (#rrggbb)",
+					},
+					&cli.StringFlag{
+						Name:  "button-text-color",
+						Usage: "Button text color (#rrggbb)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					settings, err := client.GetPortalSettings(ctx, c.String("site"))
+					if err != nil {
+						return err
+					}
+
+					applyPortalFlags(c, settings)
+
+					updated, err := client.UpdatePortalSettings(ctx, c.String("site"), *settings)
+					if err != nil {
+						return err
+					}
+
+					printPortalSettings(updated)
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// applyPortalFlags copies the portal flags the user set onto settings
+func applyPortalFlags(c *cli.Context, settings *unifi.PortalSettings) {
+	fields := map[string]*string{
+		"title":             &settings.Title,
+		"welcome":           &settings.WelcomeText,
+		"bg-color":          &settings.BackgroundColor,
+		"text-color":        &settings.TextColor,
+		"link-color":        &settings.LinkColor,
+		"box-color":         &settings.BoxColor,
+		"button-color":      &settings.ButtonColor,
+		"button-text-color": &settings.ButtonTextColor,
+	}
+	for name, field := range fields {
+		if c.IsSet(name) {
+			*field = c.String(name)
+		}
+	}
+	if c.IsSet("terms") {
+		settings.Terms = c.String("terms")
+		settings.TermsEnabled = settings.Terms != ""
+	}
+}
+
+// printGuestControl prints guest control settings as a key/value list
+func printGuestControl(settings *unifi.GuestControl) {
+	redirect := "off"
+	if settings.RedirectEnabled {
+		redirect = settings.RedirectURL
+	}
+
+	fmt.Printf("%-10s %t\n", "Portal:", settings.PortalEnabled)
+	fmt.Printf("%-10s %s\n", "Auth:", settings.AuthType)
+	fmt.Printf("%-10s %d minutes\n", "Expire:", settings.ExpireMinutes)
+	fmt.Printf("%-10s %s\n", "Redirect:", redirect)
+	fmt.Printf("%-10s %t\n", "Isolation:", settings.Isolation)
+}
+
+// printPortalSettings prints portal settings as a key/value list
+func printPortalSettings(settings *unifi.PortalSettings) {
+	terms := "off"
+	if settings.TermsEnabled {
+		terms = truncateString(settings.Terms, 60)
+	}
+
+	fmt.Printf("%-12s %s\n", "Title:", settings.Title)
+	fmt.Printf("%-12s %s\n", "Welcome:", settings.WelcomeText)
+	fmt.Printf("%-12s %s\n", "Terms:", terms)
+	fmt.Printf("%-12s %s\n", "Background:", colorOrDefault(settings.BackgroundColor))
+	fmt.Printf("%-12s %s\n", "Text:", colorOrDefault(settings.TextColor))
+	fmt.Printf("%-12s %s\n", "Link:", colorOrDefault(settings.LinkColor))
+	fmt.Printf("%-12s %s\n", "Box:", colorOrDefault(settings.BoxColor))
+	fmt.Printf("%-12s %s\n", "Button:", colorOrDefault(settings.ButtonColor))
+	fmt.Printf("%-12s %s\n", "Button text:", colorOrDefault(settings.ButtonTextColor))
+}
+
+// colorOrDefault returns color, or "default" when the theme leaves it unset
+func colorOrDefault(color string) string {
+	if color == "" {
+		return "default"
+	}
+	return color
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+func TestApplyPortalFlags(t *testing.T) {
+	newContext := func(t *testing.T, args ...string) *cli.Context {
+		t.Helper()
+		set := flag.NewFlagSet("set", flag.ContinueOnError)
+		for _, name := range []string{"title", "welcome", "terms", "bg-color", "text-color", "link-color", "box-color", "button-color", "button-text-color"} {
+			set.String(name, "", "")
+		}
+		if err := set.Parse(args); err != nil {
+			t.Fatalf("failed to parse flags: %v", err)
+		}
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+
+	current := unifi.PortalSettings{
+		Title:        "Guests",
+		WelcomeText:  "Hello",
+		TermsEnabled: true,
+		Terms:        "Be nice.",
+		PortalTheme:  unifi.PortalTheme{BackgroundColor: "#fff", ButtonColor: "#000"},
+	}
+
+	t.Run("unset flags keep current values", func(t *testing.T) {
+		settings := current
+		applyPortalFlags(newContext(t, "--title", "Visitors", "--button-color", "#0066cc"), &settings)
+
+		want := current
+		want.Title = "Visitors"
+		want.ButtonColor = "#0066cc"
+		if settings != want {
+			t.Errorf("expected %+v, got %+v", want, settings)
+		}
+	})
+
+	t.Run("empty terms disables them", func(t *testing.T) {
+		settings := current
+		applyPortalFlags(newContext(t, "--terms", ""), &settings)
+
+		if settings.TermsEnabled || settings.Terms != "" {
+			t.Errorf("expected terms disabled, got enabled=%t terms=%q", settings.TermsEnabled, settings.Terms)
+		}
+	})
+
+	t.Run("terms enables them", func(t *testing.T) {
+		settings := unifi.PortalSettings{Title: "Guests"}
+		applyPortalFlags(newContext(t, "--terms", "No streaming."), &settings)
+
+		if !settings.TermsEnabled || settings.Terms != "No streaming." {
+			t.Errorf("expected terms enabled, got enabled=%t terms=%q", settings.TermsEnabled, settings.Terms)
+		}
+	})
+}
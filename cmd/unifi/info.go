@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -27,7 +26,7 @@ func appInfoCommand() *cli.Command {
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := c.Context
 			info, err := client.GetApplicationInfo(ctx)
 			if err != nil {
 				return fmt.Errorf("failed to get application info: %w", err)
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+func logsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "logs",
+		Usage: "View the controller's system log",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "site",
+				Aliases: []string{"s"},
+				Usage:   "Site ID",
+				Value:   "default",
+			},
+			&cli.DurationFlag{
+				Name:  "since",
+				Usage: "Only show entries newer than this duration (e.g. 1h, 30m)",
+			},
+			&cli.StringFlag{
+				Name:  "level",
+				Usage: "Filter by level (debug, info, warn, error)",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "Maximum number of entries to return (0-200, -1 for max)",
+				Value: 25,
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "Starting offset for pagination",
+				Value: 0,
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output in JSON format",
+				Value: false,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			client, err := createClient(c)
+			if err != nil {
+				return err
+			}
+
+			params := &unifi.LogParams{
+				Limit:  c.Int("limit"),
+				Offset: c.Int("offset"),
+				Level:  c.String("level"),
+			}
+			if since := c.Duration("since"); since > 0 {
+				params.Start = time.Now().Add(-since)
+			}
+
+			ctx := c.Context
+			resp, err := client.GetSystemLog(ctx, c.String("site"), params)
+			if err != nil {
+				return err
+			}
+
+			if c.Bool("json") {
+				return json.NewEncoder(os.Stdout).Encode(resp)
+			}
+
+			// Table output
+			fmt.Printf("%-20s %-6s %-12s %-30s\n", "TIME", "LEVEL", "SUBSYSTEM", "MESSAGE")
+			fmt.Println(strings.Repeat("-", 90))
+			for _, entry := range resp.Data {
+				fmt.Printf("%-20s %-6s %-12s %-30s\n",
+					entry.Timestamp().Format(time.DateTime),
+					entry.Level,
+					truncateString(entry.Subsystem, 11),
+					entry.Message,
+				)
+			}
+
+			fmt.Printf("\n%s\n", formatPageSummary(resp.Count, resp.TotalCount, resp.Offset, "entries"))
+			return nil
+		},
+	}
+}
@@ -1,14 +1,23 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/klauern/unifi-network-go"
 	"github.com/urfave/cli/v2"
 )
 
 func main() {
+	var debugErrors bool
 	app := &cli.App{
 		Name:  "unifi",
 		Usage: "UniFi Network API CLI",
@@ -30,20 +39,109 @@ func main() {
 				Usage:   "Skip TLS certificate verification",
 				EnvVars: []string{"UNIFI_INSECURE"},
 			},
+			&cli.BoolFlag{
+				Name:        "debug-errors",
+				Usage:       "Print the controller's raw response body when a request fails",
+				EnvVars:     []string{"UNIFI_DEBUG_ERRORS"},
+				Destination: &debugErrors,
+			},
 		},
 		Commands: []*cli.Command{
 			clientsCommand(),
 			devicesCommand(),
+			backupsCommand(),
+			eventsCommand(),
+			logsCommand(),
+			alarmsCommand(),
 			hotspotVouchersCommand(),
+			guestControlCommand(),
+			networksCommand(),
+			radiusCommand(),
 			sitesCommand(),
+			speedTestCommand(),
 			appInfoCommand(),
+			controllerCommand(),
+			doctorCommand(),
+			reportCommand(),
 		},
 	}
 
-	if err := app.Run(os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(1)
+	ctx, stop := signalContext(context.Background())
+	defer stop()
+
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		stop()
+		fmt.Fprint(os.Stderr, formatError(err, debugErrors))
+		os.Exit(exitCode(err))
+	}
+}
+
+// shutdownSignals cancel the root context so in-flight requests are aborted
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// signalContext returns a context that is cancelled when the process receives
+// one of shutdownSignals. Commands get it through cli.Context.Context.
+func signalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, shutdownSignals...)
+}
+
+// Exit codes for failed commands
+const (
+	exitError    = 1 // Any other error
+	exitAuth     = 2 // The controller rejected the API key (401/403)
+	exitNotFound = 3 // The requested resource does not exist
+)
+
+// exitCode maps an error to the process exit code
+func exitCode(err error) int {
+	var apiErr *unifi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Status {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return exitAuth
+		}
+	}
+	if unifi.IsNotFound(err) {
+		return exitNotFound
 	}
+	return exitError
+}
+
+// formatError renders an error for stderr, adding the structured fields of
+// an API error when one is present. With rawBody set, the controller's
+// response body is appended as well, indented JSON when it parses.
+func formatError(err error, rawBody bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "error: %v\n", err)
+
+	var apiErr *unifi.Error
+	if errors.As(err, &apiErr) {
+		fmt.Fprintf(&b, "  status:     %d %s\n", apiErr.Status, apiErr.StatusName)
+		fmt.Fprintf(&b, "  message:    %s\n", apiErr.Message)
+		if apiErr.RequestPath != "" {
+			fmt.Fprintf(&b, "  path:       %s\n", apiErr.RequestPath)
+		}
+		if apiErr.RequestID != "" {
+			fmt.Fprintf(&b, "  request id: %s\n", apiErr.RequestID)
+		}
+		if rawBody && len(apiErr.RawBody) > 0 {
+			fmt.Fprintf(&b, "  raw body:\n%s\n", indentBody(apiErr.RawBody, "    "))
+		}
+	}
+
+	return b.String()
+}
+
+// indentBody pretty-prints a JSON body, or returns any other body as text,
+// with every line prefixed by prefix
+func indentBody(body []byte, prefix string) string {
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, body, prefix, "  "); err == nil {
+		return prefix + pretty.String()
+	}
+
+	text := strings.TrimRight(string(body), "\n")
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
 }
 
 func createClient(c *cli.Context) (*unifi.Client, error) {
@@ -58,3 +156,8 @@ func createClient(c *cli.Context) (*unifi.Client, error) {
 
 	return client, nil
 }
+
+// formatPageSummary describes which slice of a paginated listing was shown
+func formatPageSummary(count, totalCount, offset int, noun string) string {
+	return fmt.Sprintf("Showing %d of %d %s (offset: %d)", count, totalCount, noun, offset)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "plain error", err: errors.New("boom"), want: exitError},
+		{name: "unauthorized", err: &unifi.Error{Status: 401}, want: exitAuth},
+		{name: "forbidden", err: &unifi.Error{Status: 403}, want: exitAuth},
+		{name: "wrapped unauthorized", err: fmt.Errorf("failed to list devices: %w", &unifi.Error{Status: 401}), want: exitAuth},
+		{name: "API not found", err: fmt.Errorf("failed to get device: %w", &unifi.Error{Status: 404}), want: exitNotFound},
+		{name: "empty lookup", err: &unifi.NotFoundError{Resource: "device", ID: "x"}, want: exitNotFound},
+		{name: "server error", err: &unifi.Error{Status: 500}, want: exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	t.Run("plain error", func(t *testing.T) {
+		got := formatError(errors.New("boom"), false)
+		if want := "error: boom\n"; got != want {
+			t.Errorf("formatError() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		apiErr := &unifi.Error{
+			Status:      404,
+			StatusName:  "Not Found",
+			Message:     "Device not found",
+			RequestPath: "/proxy/network/integration/v1/sites/default/devices/x",
+			RequestID:   "req-123",
+		}
+
+		got := formatError(fmt.Errorf("failed to get device: %w", apiErr), false)
+		want := "error: failed to get device: " + apiErr.Error() + "\n" +
+			"  status:     404 Not Found\n" +
+			"  message:    Device not found\n" +
+			"  path:       /proxy/network/integration/v1/sites/default/devices/x\n" +
+			"  request id: req-123\n"
+		if got != want {
+			t.Errorf("formatError() =\n%s\nwant\n%s", got, want)
+		}
+	})
+}
+
+func TestFormatError_RawBody(t *testing.T) {
+	apiErr := &unifi.Error{
+		Status:     400,
+		StatusName: "Bad Request",
+		Message:    "invalid",
+		RawBody:    []byte(`{"statusCode":400,"extra":{"field":"name"}}`),
+	}
+	header := "error: " + apiErr.Error() + "\n" +
+		"  status:     400 Bad Request\n" +
+		"  message:    invalid\n"
+
+	t.Run("hidden by default", func(t *testing.T) {
+		if got := formatError(apiErr, false); got != header {
+			t.Errorf("formatError() =\n%s\nwant\n%s", got, header)
+		}
+	})
+
+	t.Run("JSON body is indented", func(t *testing.T) {
+		want := header +
+			"  raw body:\n" +
+			"    {\n" +
+			"      \"statusCode\": 400,\n" +
+			"      \"extra\": {\n" +
+			"        \"field\": \"name\"\n" +
+			"      }\n" +
+			"    }\n"
+		if got := formatError(apiErr, true); got != want {
+			t.Errorf("formatError() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("non-JSON body", func(t *testing.T) {
+		htmlErr := *apiErr
+		htmlErr.RawBody = []byte("<html>\n<body>Upgrading</body>\n</html>\n")
+		want := "error: " + htmlErr.Error() + "\n" +
+			"  status:     400 Bad Request\n" +
+			"  message:    invalid\n" +
+			"  raw body:\n" +
+			"    <html>\n" +
+			"    <body>Upgrading</body>\n" +
+			"    </html>\n"
+		if got := formatError(&htmlErr, true); got != want {
+			t.Errorf("formatError() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("no raw body", func(t *testing.T) {
+		noBody := *apiErr
+		noBody.RawBody = nil
+		if got := formatError(&noBody, true); got != header {
+			t.Errorf("formatError() =\n%s\nwant\n%s", got, header)
+		}
+	})
+}
+
+func TestSignalContext(t *testing.T) {
+	t.Run("cancelled by SIGTERM", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("signals cannot be sent to the current process on windows")
+		}
+
+		ctx, stop := signalContext(context.Background())
+		defer stop()
+
+		proc, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			t.Fatalf("failed to find current process: %v", err)
+		}
+		if err := proc.Signal(syscall.SIGTERM); err != nil {
+			t.Fatalf("failed to send signal: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("context was not cancelled by SIGTERM")
+		}
+	})
+
+	t.Run("cancelled with parent", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		ctx, stop := signalContext(parent)
+		defer stop()
+
+		cancel()
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("context was not cancelled with its parent")
+		}
+	})
+
+	t.Run("stop releases the context", func(t *testing.T) {
+		ctx, stop := signalContext(context.Background())
+		stop()
+		if ctx.Err() == nil {
+			t.Error("expected context to be done after stop")
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Output formats accepted by --format
+const (
+	formatTable  = "table"
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+// formatFlag selects how list commands print results
+func formatFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format (table, json, ndjson); ndjson streams every item, following pagination",
+		Value: formatTable,
+	}
+}
+
+// outputFormat returns the format chosen with --format, treating --json as
+// shorthand for --format json
+func outputFormat(c *cli.Context) (string, error) {
+	format := c.String("format")
+	switch format {
+	case formatTable, formatJSON, formatNDJSON:
+	default:
+		return "", fmt.Errorf("invalid format %q (supported: table, json, ndjson)", format)
+	}
+
+	if c.Bool("json") {
+		if c.IsSet("format") && format != formatJSON {
+			return "", fmt.Errorf("--json conflicts with --format %s", format)
+		}
+		return formatJSON, nil
+	}
+	return format, nil
+}
+
+// detailFormatFlag selects how single-item commands print their result. JSON
+// is the default so the output can be piped into tools such as jq.
+func detailFormatFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format (json, table)",
+		Value: formatJSON,
+	}
+}
+
+// detailOutputFormat returns the format chosen with detailFormatFlag
+func detailOutputFormat(c *cli.Context) (string, error) {
+	format := c.String("format")
+	switch format {
+	case formatJSON, formatTable:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid format %q (supported: json, table)", format)
+	}
+}
+
+// rejectNDJSONFlags returns an error if any of the named flags was set while
+// --format ndjson is in use. ndjson always streams every item, so flags that
+// page or reshape the output would otherwise be silently ignored.
+func rejectNDJSONFlags(c *cli.Context, format string, names ...string) error {
+	if format != formatNDJSON {
+		return nil
+	}
+	for _, name := range names {
+		if c.IsSet(name) {
+			return fmt.Errorf("--%s cannot be used with --format ndjson, which streams every item", name)
+		}
+	}
+	return nil
+}
+
+// writeNDJSON writes each item from seq as a single-line JSON object as soon
+// as it is yielded, so output can be piped into line-oriented tools while
+// later pages are still being fetched. It stops at the first error.
+func writeNDJSON[T any](w io.Writer, seq iter.Seq2[T, error]) error {
+	enc := json.NewEncoder(w)
+	for item, err := range seq {
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(item); err != nil {
+			return fmt.Errorf("failed to write item: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+// seqOf yields items with no error, mimicking a client iterator
+func seqOf[T any](items []T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	devices := []unifi.Device{
+		{ID: "d1", Name: "Office AP"},
+		{ID: "d2", Name: "Core\nSwitch"},
+		{ID: "d3", Name: "Garage"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeNDJSON(&buf, seqOf(devices)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(devices) {
+		t.Fatalf("expected %d lines, got %d: %q", len(devices), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var got unifi.Device
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v", i, err)
+		}
+		if got.ID != devices[i].ID || got.Name != devices[i].Name {
+			t.Errorf("line %d: expected %s/%q, got %s/%q", i, devices[i].ID, devices[i].Name, got.ID, got.Name)
+		}
+	}
+
+	t.Run("no items", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeNDJSON(&buf, seqOf([]unifi.Site(nil))); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected no output, got %q", buf.String())
+		}
+	})
+
+	t.Run("error stops output", func(t *testing.T) {
+		boom := errors.New("boom")
+		seq := func(yield func(unifi.Site, error) bool) {
+			if !yield(unifi.Site{ID: "default"}, nil) {
+				return
+			}
+			yield(unifi.Site{}, boom)
+		}
+
+		var buf bytes.Buffer
+		if err := writeNDJSON(&buf, seq); !errors.Is(err, boom) {
+			t.Fatalf("expected boom error, got %v", err)
+		}
+		if lines := slices.Collect(strings.Lines(buf.String())); len(lines) != 1 {
+			t.Errorf("expected the item before the error to be written, got %q", buf.String())
+		}
+	})
+}
+
+func TestRejectNDJSONFlags(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	run := func(args ...string) error {
+		app := &cli.App{
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "url"},
+				&cli.StringFlag{Name: "api-key"},
+				&cli.BoolFlag{Name: "insecure"},
+			},
+			Commands: []*cli.Command{devicesCommand(), clientsCommand(), sitesCommand(), hotspotVouchersCommand()},
+		}
+		return app.Run(append([]string{"unifi", "--url", server.URL, "--api-key", "test-api-key"}, args...))
+	}
+
+	tests := []struct {
+		args []string
+		flag string
+	}{
+		{[]string{"devices", "list", "--format", "ndjson", "--with-meta"}, "--with-meta"},
+		{[]string{"devices", "list", "--format", "ndjson", "--limit", "5"}, "--limit"},
+		{[]string{"clients", "list", "--format", "ndjson", "--group-by", "network"}, "--group-by"},
+		{[]string{"sites", "list", "--format", "ndjson", "--limit", "5"}, "--limit"},
+		{[]string{"vouchers", "list", "--format", "ndjson", "--limit", "5"}, "--limit"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			requests = 0
+			err := run(tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.flag+" cannot be used with --format ndjson") {
+				t.Fatalf("expected %s conflict error, got %v", tt.flag, err)
+			}
+			if requests != 0 {
+				t.Errorf("expected no requests, got %d", requests)
+			}
+		})
+	}
+
+	t.Run("flags allowed with other formats", func(t *testing.T) {
+		if err := run("devices", "list", "--format", "json", "--with-meta", "--limit", "5"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestDetailOutputFormat(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{args: nil, want: formatJSON},
+		{args: []string{"--format", "table"}, want: formatTable},
+		{args: []string{"--format", "ndjson"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var got string
+			app := &cli.App{
+				Flags: []cli.Flag{detailFormatFlag()},
+				Action: func(c *cli.Context) error {
+					var err error
+					got, err = detailOutputFormat(c)
+					return err
+				},
+			}
+
+			err := app.Run(append([]string{"unifi"}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected format %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+func networksCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "networks",
+		Aliases: []string{"n"},
+		Usage:   "Manage UniFi networks and VLANs",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List configured networks",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					networks, err := client.ListNetworks(ctx, c.String("site"))
+					if err != nil {
+						return fmt.Errorf("failed to list networks: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(networks)
+					}
+
+					// Table output
+					fmt.Printf("%-24s %-6s %-18s %-6s %-16s\n", "NAME", "VLAN", "SUBNET", "DHCP", "PURPOSE")
+					fmt.Println(strings.Repeat("-", 74))
+					for _, network := range networks {
+						vlan := "-"
+						if network.VLANEnabled {
+							vlan = fmt.Sprint(network.VLAN)
+						}
+						dhcp := "off"
+						if network.DHCPEnabled {
+							dhcp = "on"
+						}
+
+						fmt.Printf("%-24s %-6s %-18s %-6s %-16s\n",
+							truncateString(network.Name, 23),
+							vlan,
+							network.Subnet,
+							dhcp,
+							network.Purpose,
+						)
+					}
+
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+func radiusCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "radius",
+		Usage: "Manage RADIUS user accounts",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List RADIUS accounts",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					accounts, err := client.ListRadiusAccounts(ctx, c.String("site"))
+					if err != nil {
+						return err
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(accounts)
+					}
+
+					// Table output
+					fmt.Printf("%-26s %-32s %-6s\n", "ID", "USERNAME", "VLAN")
+					fmt.Println(strings.Repeat("-", 66))
+					for _, account := range accounts {
+						vlan := "-"
+						if account.VLAN > 0 {
+							vlan = fmt.Sprint(account.VLAN)
+						}
+						fmt.Printf("%-26s %-32s %-6s\n",
+							account.ID,
+							truncateString(account.Name, 31),
+							vlan,
+						)
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:  "create",
+				Usage: "Create a RADIUS account",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "username",
+						Usage:    "Account username",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "password",
+						Usage:    "Account password (at least 8 characters mixing letters, digits and symbols)",
+						EnvVars:  []string{"UNIFI_RADIUS_PASSWORD"},
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:  "vlan",
+						Usage: "VLAN to assign on authentication",
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					account, err := client.CreateRadiusAccount(ctx, c.String("site"), &unifi.RadiusAccount{
+						Name:     c.String("username"),
+						Password: c.String("password"),
+						VLAN:     c.Int("vlan"),
+					})
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("Successfully created RADIUS account %s (%s)\n", account.Name, account.ID)
+					return nil
+				},
+			},
+			{
+				Name:  "delete",
+				Usage: "Delete a RADIUS account",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Account ID",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if err := client.DeleteRadiusAccount(ctx, c.String("site"), c.String("id")); err != nil {
+						return err
+					}
+
+					fmt.Printf("Successfully deleted RADIUS account %s\n", c.String("id"))
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+func reportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Summarize a site's devices, clients and vouchers",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "site",
+				Aliases: []string{"s"},
+				Usage:   "Site ID",
+				Value:   "default",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output in JSON format",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			client, err := createClient(c)
+			if err != nil {
+				return err
+			}
+
+			ctx := c.Context
+			report, err := client.GetSiteReport(ctx, c.String("site"))
+			if report == nil {
+				return err
+			}
+
+			// A partial report is still printed, followed by the error
+			if c.Bool("json") {
+				if encErr := json.NewEncoder(os.Stdout).Encode(report); encErr != nil {
+					return encErr
+				}
+				return err
+			}
+
+			writeSiteReport(os.Stdout, report)
+			return err
+		},
+	}
+}
+
+// writeSiteReport renders a site report as plain text
+func writeSiteReport(w io.Writer, r *unifi.SiteReport) {
+	name := r.Site.Name
+	if name == "" {
+		name = r.Site.ID
+	}
+
+	fmt.Fprintf(w, "Site report: %s\n", name)
+	fmt.Fprintf(w, "Generated:   %s\n", r.GeneratedAt.Format(time.DateTime))
+	fmt.Fprintln(w, strings.Repeat("-", 40))
+	fmt.Fprintf(w, "%-20s %d%s\n", "Devices", r.Devices, formatCounts(r.DevicesByStatus))
+	fmt.Fprintf(w, "%-20s %d\n", "Outdated firmware", r.OutdatedDevices)
+	fmt.Fprintf(w, "%-20s %d%s\n", "Clients", r.Clients, formatCounts(r.ClientsByType))
+	fmt.Fprintf(w, "%-20s %d\n", "Active vouchers", r.ActiveVouchers)
+}
+
+// formatCounts renders a breakdown such as " (offline 1, online 2)", sorted
+// by key, or "" when there is nothing to break down
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		label := strings.ToLower(k)
+		if label == "" {
+			label = "unknown"
+		}
+		parts[i] = fmt.Sprintf("%s %d", label, counts[k])
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
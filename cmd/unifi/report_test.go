@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+func TestWriteSiteReport(t *testing.T) {
+	report := &unifi.SiteReport{
+		Site:            unifi.Site{ID: "default", Name: "Main Office"},
+		GeneratedAt:     time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC),
+		Devices:         3,
+		DevicesByStatus: map[string]int{unifi.DeviceStatusOnline: 2, unifi.DeviceStatusOffline: 1},
+		OutdatedDevices: 1,
+		Clients:         5,
+		ClientsByType:   map[string]int{"WIRELESS": 4, "WIRED": 1},
+		ActiveVouchers:  7,
+	}
+
+	var buf bytes.Buffer
+	writeSiteReport(&buf, report)
+	out := buf.String()
+
+	for _, want := range []string{
+		"Site report: Main Office",
+		"Generated:   2024-06-01 02:00:00",
+		"Devices              3 (offline 1, online 2)",
+		"Outdated firmware    1",
+		"Clients              5 (wired 1, wireless 4)",
+		"Active vouchers      7",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatCounts(t *testing.T) {
+	if got := formatCounts(nil); got != "" {
+		t.Errorf("formatCounts(nil) = %q, want empty", got)
+	}
+	if got := formatCounts(map[string]int{"": 2}); got != " (unknown 2)" {
+		t.Errorf("formatCounts(empty key) = %q", got)
+	}
+}
+
+func TestReportCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/proxy/network/integration") {
+		case "/v1/sites/default":
+			w.Write([]byte(`{"data":[{"id":"default","name":"Main Office"}]}`))
+		case "/v1/sites/default/devices", "/v1/sites/default/clients":
+			w.Write([]byte(`{"data":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode":404,"statusName":"Not Found","message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	app := &cli.App{
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "url"},
+			&cli.StringFlag{Name: "api-key"},
+			&cli.BoolFlag{Name: "insecure"},
+		},
+		Commands: []*cli.Command{reportCommand()},
+	}
+
+	// Vouchers are missing, so the partial report is printed and the error returned
+	err := app.Run([]string{"unifi", "--url", server.URL, "--api-key", "test-api-key", "report", "--site", "default"})
+	if err == nil || !strings.Contains(err.Error(), "failed to list hotspot vouchers") {
+		t.Fatalf("expected voucher error, got %v", err)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -23,7 +22,7 @@ func sitesCommand() *cli.Command {
 				Flags: []cli.Flag{
 					&cli.IntFlag{
 						Name:  "limit",
-						Usage: "Maximum number of sites to return (0-200)",
+						Usage: "Maximum number of sites to return (0-200, -1 for max)",
 						Value: 25,
 					},
 					&cli.IntFlag{
@@ -36,6 +35,7 @@ func sitesCommand() *cli.Command {
 						Usage: "Output in JSON format",
 						Value: false,
 					},
+					formatFlag(),
 				},
 				Action: func(c *cli.Context) error {
 					client, err := createClient(c)
@@ -43,18 +43,33 @@ func sitesCommand() *cli.Command {
 						return err
 					}
 
+					format, err := outputFormat(c)
+					if err != nil {
+						return err
+					}
+
+					if err := rejectNDJSONFlags(c, format, "limit"); err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if format == formatNDJSON {
+						return writeNDJSON(os.Stdout, client.IterSites(ctx, &unifi.ListSitesParams{
+							Offset: c.Int("offset"),
+						}))
+					}
+
 					params := &unifi.ListSitesParams{
 						Limit:  c.Int("limit"),
 						Offset: c.Int("offset"),
 					}
 
-					ctx := context.Background()
 					resp, err := client.ListSites(ctx, params)
 					if err != nil {
 						return fmt.Errorf("failed to list sites: %w", err)
 					}
 
-					if c.Bool("json") {
+					if format == formatJSON {
 						return json.NewEncoder(os.Stdout).Encode(resp)
 					}
 
@@ -68,8 +83,43 @@ func sitesCommand() *cli.Command {
 						)
 					}
 
-					fmt.Printf("\nShowing %d of %d sites (offset: %d)\n",
-						resp.Count, resp.TotalCount, resp.Offset)
+					fmt.Printf("\n%s\n", formatPageSummary(resp.Count, resp.TotalCount, resp.Offset, "sites"))
+					return nil
+				},
+			},
+			{
+				Name:  "get",
+				Usage: "Get site details",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Site ID",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					site, err := client.GetSite(ctx, c.String("id"))
+					if err != nil {
+						return fmt.Errorf("failed to get site: %w", err)
+					}
+
+					if c.Bool("json") {
+						return json.NewEncoder(os.Stdout).Encode(site)
+					}
+
+					fmt.Printf("%-6s %s\n", "ID:", site.ID)
+					fmt.Printf("%-6s %s\n", "Name:", site.Name)
 					return nil
 				},
 			},
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+	"github.com/urfave/cli/v2"
+)
+
+func speedTestCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "speedtest",
+		Usage: "Run and inspect gateway speed tests",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "run",
+				Usage: "Run a speed test and wait for the result",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.DurationFlag{
+						Name:  "timeout",
+						Usage: "How long to wait for the result",
+						Value: 2 * time.Minute,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx, cancel := context.WithTimeout(c.Context, c.Duration("timeout"))
+					defer cancel()
+
+					result, err := client.RunSpeedTest(ctx, c.String("site"))
+					if err != nil {
+						return fmt.Errorf("failed to run speed test: %w", err)
+					}
+
+					return printSpeedTest(result, c.Bool("json"))
+				},
+			},
+			{
+				Name:  "last",
+				Usage: "Show the most recent speed test result",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output in JSON format",
+						Value: false,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					result, err := client.GetLastSpeedTest(ctx, c.String("site"))
+					if err != nil {
+						return fmt.Errorf("failed to get speed test result: %w", err)
+					}
+
+					return printSpeedTest(result, c.Bool("json"))
+				},
+			},
+		},
+	}
+}
+
+// printSpeedTest prints a speed test result as JSON or a short summary
+func printSpeedTest(result *unifi.SpeedTestResult, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+
+	fmt.Printf("%-10s %s\n", "Run At:", result.RunTime().Format(time.RFC3339))
+	fmt.Printf("%-10s %.1f Mbps\n", "Download:", result.DownloadMbps)
+	fmt.Printf("%-10s %.1f Mbps\n", "Upload:", result.UploadMbps)
+	fmt.Printf("%-10s %.0f ms\n", "Latency:", result.LatencyMs)
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+// Voucher sheet layout on a US Letter page, in PDF points
+const (
+	sheetPageWidth   = 612
+	sheetPageHeight  = 792
+	sheetMargin      = 36
+	sheetColumns     = 2
+	sheetRows        = 5
+	vouchersPerSheet = sheetColumns * sheetRows
+)
+
+// sheetPageCount returns the number of pages needed to print n vouchers.
+// An empty sheet still produces a single blank page.
+func sheetPageCount(n int) int {
+	if n <= 0 {
+		return 1
+	}
+	return (n + vouchersPerSheet - 1) / vouchersPerSheet
+}
+
+// writeVoucherSheet renders vouchers as a printable grid of cards to a PDF.
+// The sheet only needs text and rectangles in the built-in Helvetica fonts,
+// so it writes the handful of PDF objects directly rather than adding a PDF
+// dependency to the CLI. Text is left-aligned and truncated by character
+// count, so no font metrics are needed.
+func writeVoucherSheet(w io.Writer, vouchers []unifi.HotspotVoucher) error {
+	pages := sheetPageCount(len(vouchers))
+
+	// Object layout: 1 catalog, 2 page tree, 3 regular font, 4 bold font,
+	// then a page object followed by its content stream for every page
+	const firstPageObj = 5
+	var kids []string
+	for i := 0; i < pages; i++ {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPageObj+2*i))
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pages),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	for i := 0; i < pages; i++ {
+		start := i * vouchersPerSheet
+		end := min(start+vouchersPerSheet, len(vouchers))
+		var content string
+		if start < end {
+			content = voucherPageContent(vouchers[start:end])
+		}
+
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				sheetPageWidth, sheetPageHeight, firstPageObj+2*i+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+// voucherPageContent builds the content stream drawing one page of voucher cards
+func voucherPageContent(vouchers []unifi.HotspotVoucher) string {
+	cardWidth := float64(sheetPageWidth-2*sheetMargin) / sheetColumns
+	cardHeight := float64(sheetPageHeight-2*sheetMargin) / sheetRows
+
+	var b strings.Builder
+	b.WriteString("0.5 w\n")
+	for i, voucher := range vouchers {
+		x := sheetMargin + float64(i%sheetColumns)*cardWidth
+		y := sheetPageHeight - sheetMargin - float64(i/sheetColumns+1)*cardHeight
+
+		// Dashed cut lines around each card
+		fmt.Fprintf(&b, "[4 2] 0 d %.2f %.2f %.2f %.2f re S [] 0 d\n", x, y, cardWidth, cardHeight)
+
+		expires := "Never"
+		if voucher.ExpiresAt != "" {
+			expires = voucher.ExpiresAt
+		}
+
+		textX := x + 18
+		top := y + cardHeight
+		writePDFText(&b, "F1", 10, textX, top-28, "Wi-Fi voucher")
+		writePDFText(&b, "F2", 24, textX, top-62, formatVoucherCode(voucher.Code))
+		if voucher.Name != "" {
+			writePDFText(&b, "F1", 10, textX, top-88, truncateString(voucher.Name, 45))
+		}
+		writePDFText(&b, "F1", 9, textX, top-106, fmt.Sprintf("Valid for %d minutes", voucher.TimeLimitMinutes))
+		writePDFText(&b, "F1", 9, textX, top-120, "Expires: "+expires)
+	}
+	return b.String()
+}
+
+// formatVoucherCode splits a 10-digit voucher code as the controller UI does (12345-67890)
+func formatVoucherCode(code string) string {
+	if len(code) == 10 && !strings.Contains(code, "-") {
+		return code[:5] + "-" + code[5:]
+	}
+	return code
+}
+
+// writePDFText appends a single line of text at the given position
+func writePDFText(b *strings.Builder, font string, size int, x, y float64, text string) {
+	fmt.Fprintf(b, "BT /%s %d Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escapePDFString(text))
+}
+
+// escapePDFString escapes a PDF literal string. Characters outside printable
+// ASCII are replaced since the standard fonts only cover a single-byte encoding.
+func escapePDFString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+func TestWriteVoucherSheet(t *testing.T) {
+	pageObj := regexp.MustCompile(`/Type /Page\b[^s]`)
+
+	tests := []struct {
+		vouchers int
+		pages    int
+	}{
+		{vouchers: 0, pages: 1},
+		{vouchers: 1, pages: 1},
+		{vouchers: vouchersPerSheet, pages: 1},
+		{vouchers: vouchersPerSheet + 1, pages: 2},
+		{vouchers: 3*vouchersPerSheet - 1, pages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d vouchers", tt.vouchers), func(t *testing.T) {
+			vouchers := make([]unifi.HotspotVoucher, tt.vouchers)
+			for i := range vouchers {
+				vouchers[i] = unifi.HotspotVoucher{
+					Code:             fmt.Sprintf("%010d", i),
+					Name:             "Lobby (guest)",
+					ExpiresAt:        "2024-01-01T00:00:00Z",
+					TimeLimitMinutes: 60,
+				}
+			}
+
+			var buf bytes.Buffer
+			if err := writeVoucherSheet(&buf, vouchers); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			pdf := buf.String()
+
+			if !strings.HasPrefix(pdf, "%PDF-1.4\n") {
+				t.Errorf("missing PDF header: %q", pdf[:min(len(pdf), 16)])
+			}
+			if !strings.HasSuffix(pdf, "%%EOF\n") {
+				t.Error("missing EOF marker")
+			}
+			if got := len(pageObj.FindAllString(pdf, -1)); got != tt.pages {
+				t.Errorf("expected %d page objects, got %d", tt.pages, got)
+			}
+			if !strings.Contains(pdf, fmt.Sprintf("/Count %d ", tt.pages)) {
+				t.Errorf("expected page tree /Count %d", tt.pages)
+			}
+
+			checkPDFStructure(t, pdf)
+
+			if tt.vouchers > 0 {
+				if !strings.Contains(pdf, "(00000-00000)") {
+					t.Error("expected first voucher code in output")
+				}
+				if !strings.Contains(pdf, `(Lobby \(guest\))`) {
+					t.Error("expected escaped voucher note in output")
+				}
+			}
+		})
+	}
+}
+
+// checkPDFStructure verifies the cross-reference table: every entry must
+// point at the start of its object, the table must list every object, and
+// each stream's /Length must match its data.
+func checkPDFStructure(t *testing.T, pdf string) {
+	t.Helper()
+
+	idx := strings.LastIndex(pdf, "startxref\n")
+	if idx < 0 {
+		t.Fatal("missing startxref")
+	}
+	xrefOffset, err := strconv.Atoi(strings.Fields(pdf[idx+len("startxref\n"):])[0])
+	if err != nil {
+		t.Fatalf("invalid startxref: %v", err)
+	}
+
+	lines := strings.Split(pdf[xrefOffset:], "\n")
+	if lines[0] != "xref" {
+		t.Fatalf("startxref %d does not point at xref table", xrefOffset)
+	}
+	var first, size int
+	if _, err := fmt.Sscanf(lines[1], "%d %d", &first, &size); err != nil || first != 0 {
+		t.Fatalf("invalid xref subsection header %q", lines[1])
+	}
+	if lines[2] != "0000000000 65535 f " {
+		t.Errorf("invalid free entry %q", lines[2])
+	}
+
+	objects := regexp.MustCompile(`(?m)^\d+ 0 obj$`).FindAllStringIndex(pdf, -1)
+	if len(objects) != size-1 {
+		t.Errorf("xref lists %d objects, file has %d", size-1, len(objects))
+	}
+	for n := 1; n < size; n++ {
+		entry := lines[2+n]
+		if len(entry) != 19 || !strings.HasSuffix(entry, " 00000 n ") {
+			t.Errorf("object %d: malformed xref entry %q", n, entry)
+			continue
+		}
+		offset, err := strconv.Atoi(entry[:10])
+		if err != nil {
+			t.Errorf("object %d: invalid offset %q", n, entry[:10])
+			continue
+		}
+		if want := fmt.Sprintf("%d 0 obj\n", n); !strings.HasPrefix(pdf[offset:], want) {
+			t.Errorf("object %d: xref offset %d points at %q", n, offset, pdf[offset:min(len(pdf), offset+12)])
+		}
+	}
+
+	if !strings.Contains(pdf[xrefOffset:], fmt.Sprintf("/Size %d ", size)) {
+		t.Errorf("trailer /Size does not match xref size %d", size)
+	}
+
+	streams := regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)\nendstream`).FindAllStringSubmatch(pdf, -1)
+	if len(streams) == 0 {
+		t.Error("expected at least one content stream")
+	}
+	for i, m := range streams {
+		if length, _ := strconv.Atoi(m[1]); length != len(m[2]) {
+			t.Errorf("stream %d: /Length %d, data is %d bytes", i, length, len(m[2]))
+		}
+	}
+}
+
+func TestEscapePDFString(t *testing.T) {
+	got := escapePDFString(`a\b(c)d é`)
+	want := `a\\b\(c\)d ?`
+	if got != want {
+		t.Errorf("escapePDFString() = %q, want %q", got, want)
+	}
+}
@@ -1,11 +1,11 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/klauern/unifi-network-go"
 	"github.com/urfave/cli/v2"
@@ -29,14 +29,19 @@ func hotspotVouchersCommand() *cli.Command {
 					},
 					&cli.IntFlag{
 						Name:  "limit",
-						Usage: "Maximum number of vouchers to return",
+						Usage: "Maximum number of vouchers to return (0-200, -1 for max)",
 						Value: 25,
 					},
+					&cli.StringFlag{
+						Name:  "note",
+						Usage: "Only list vouchers whose note contains this text (case-insensitive)",
+					},
 					&cli.BoolFlag{
 						Name:  "json",
 						Usage: "Output in JSON format",
 						Value: false,
 					},
+					formatFlag(),
 				},
 				Action: func(c *cli.Context) error {
 					client, err := createClient(c)
@@ -44,23 +49,38 @@ func hotspotVouchersCommand() *cli.Command {
 						return err
 					}
 
+					format, err := outputFormat(c)
+					if err != nil {
+						return err
+					}
+
+					if err := rejectNDJSONFlags(c, format, "limit"); err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					if format == formatNDJSON {
+						params := &unifi.ListHotspotVouchersParams{Name: c.String("note")}
+						return writeNDJSON(os.Stdout, client.IterHotspotVouchers(ctx, c.String("site"), params))
+					}
+
 					params := &unifi.ListHotspotVouchersParams{
 						Limit: c.Int("limit"),
+						Name:  c.String("note"),
 					}
 
-					ctx := context.Background()
 					resp, err := client.ListHotspotVouchers(ctx, c.String("site"), params)
 					if err != nil {
 						return fmt.Errorf("failed to list vouchers: %w", err)
 					}
 
-					if c.Bool("json") {
+					if format == formatJSON {
 						return json.NewEncoder(os.Stdout).Encode(resp.Data)
 					}
 
 					// Table output
-					fmt.Printf("%-24s %-12s %-15s %-10s %-8s\n", "NOTE", "CODE", "EXPIRES", "LIMIT", "STATUS")
-					fmt.Println(strings.Repeat("-", 80))
+					fmt.Printf("%-24s %-12s %-15s %-10s %-10s %-20s %-8s\n", "NOTE", "CODE", "EXPIRES", "LIMIT", "DATA", "RATE", "STATUS")
+					fmt.Println(strings.Repeat("-", 111))
 					for _, voucher := range resp.Data {
 						expires := "Never"
 						if voucher.ExpiresAt != "" {
@@ -71,15 +91,18 @@ func hotspotVouchersCommand() *cli.Command {
 							status = "Expired"
 						}
 
-						fmt.Printf("%-24s %-12s %-15s %-10d %-8s\n",
+						fmt.Printf("%-24s %-12s %-15s %-10d %-10s %-20s %-8s\n",
 							truncateString(voucher.Name, 23),
 							voucher.Code,
 							expires,
 							voucher.TimeLimitMinutes,
+							formatDataLimit(voucher),
+							formatRateLimit(voucher),
 							status,
 						)
 					}
 
+					fmt.Printf("\n%s\n", formatPageSummary(resp.Count, resp.TotalCount, resp.Offset, "vouchers"))
 					return nil
 				},
 			},
@@ -151,7 +174,7 @@ func hotspotVouchersCommand() *cli.Command {
 						request.UpRateLimitKbps = c.Int("up-limit")
 					}
 
-					ctx := context.Background()
+					ctx := c.Context
 					resp, err := client.CreateHotspotVoucher(ctx, c.String("site"), request)
 					if err != nil {
 						return fmt.Errorf("failed to create voucher: %w", err)
@@ -227,7 +250,7 @@ func hotspotVouchersCommand() *cli.Command {
 						request.TxRateLimitKbps = c.Int("up-limit")
 					}
 
-					ctx := context.Background()
+					ctx := c.Context
 					resp, err := client.GenerateHotspotVouchers(ctx, c.String("site"), request)
 					if err != nil {
 						return fmt.Errorf("failed to generate vouchers: %w", err)
@@ -251,20 +274,40 @@ func hotspotVouchersCommand() *cli.Command {
 						Usage:   "Site ID",
 						Value:   "default",
 					},
+					detailFormatFlag(),
 				},
 				Action: func(c *cli.Context) error {
+					format, err := detailOutputFormat(c)
+					if err != nil {
+						return err
+					}
+
 					client, err := createClient(c)
 					if err != nil {
 						return err
 					}
 
-					ctx := context.Background()
+					ctx := c.Context
 					voucher, err := client.GetVoucherDetails(ctx, c.String("site"), c.String("id"))
 					if err != nil {
 						return fmt.Errorf("failed to get voucher details: %w", err)
 					}
 
-					return json.NewEncoder(os.Stdout).Encode(voucher)
+					if format == formatJSON {
+						return json.NewEncoder(os.Stdout).Encode(voucher)
+					}
+
+					fmt.Printf("%-14s %s\n", "ID:", voucher.ID)
+					fmt.Printf("%-14s %s\n", "Code:", formatVoucherCode(voucher.Code))
+					fmt.Printf("%-14s %s\n", "Name:", voucher.Name)
+					fmt.Printf("%-14s %s\n", "Created At:", voucher.CreatedAt)
+					fmt.Printf("%-14s %s\n", "Activated At:", voucher.ActivatedAt)
+					fmt.Printf("%-14s %d minutes\n", "Time Limit:", voucher.TimeLimitMinutes)
+					fmt.Printf("%-14s %s\n", "Remaining:", formatRemainingTime(*voucher, time.Now()))
+					fmt.Printf("%-14s %s\n", "Data Limit:", formatDataLimit(*voucher))
+					fmt.Printf("%-14s %s\n", "Rate Limit:", formatRateLimit(*voucher))
+					fmt.Printf("%-14s %d\n", "Guests:", voucher.AuthorizeGuestCount)
+					return nil
 				},
 			},
 			{
@@ -289,7 +332,7 @@ func hotspotVouchersCommand() *cli.Command {
 						return err
 					}
 
-					ctx := context.Background()
+					ctx := c.Context
 					err = client.DeleteHotspotVoucher(ctx, c.String("site"), c.String("id"))
 					if err != nil {
 						return fmt.Errorf("failed to delete voucher: %w", err)
@@ -299,6 +342,121 @@ func hotspotVouchersCommand() *cli.Command {
 					return nil
 				},
 			},
+			{
+				Name:  "active-guests",
+				Usage: "Count guests authorized by vouchers that have not expired",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					count, err := client.ActiveGuestCount(ctx, c.String("site"))
+					if err != nil {
+						return fmt.Errorf("failed to count active guests: %w", err)
+					}
+
+					fmt.Println(count)
+					return nil
+				},
+			},
+			{
+				Name:  "print",
+				Usage: "Render all vouchers to a printable PDF sheet",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "site",
+						Aliases: []string{"s"},
+						Usage:   "Site ID",
+						Value:   "default",
+					},
+					&cli.StringFlag{
+						Name:     "out",
+						Aliases:  []string{"o"},
+						Usage:    "Output PDF file",
+						Required: true,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					client, err := createClient(c)
+					if err != nil {
+						return err
+					}
+
+					ctx := c.Context
+					vouchers, err := client.ListAllHotspotVouchers(ctx, c.String("site"))
+					if err != nil {
+						return fmt.Errorf("failed to list vouchers: %w", err)
+					}
+
+					f, err := os.Create(c.String("out"))
+					if err != nil {
+						return fmt.Errorf("failed to create output file: %w", err)
+					}
+					defer f.Close()
+
+					if err := writeVoucherSheet(f, vouchers); err != nil {
+						return err
+					}
+					if err := f.Close(); err != nil {
+						return fmt.Errorf("failed to write output file: %w", err)
+					}
+
+					fmt.Printf("Wrote %d vouchers on %d pages to %s\n",
+						len(vouchers), sheetPageCount(len(vouchers)), c.String("out"))
+					return nil
+				},
+			},
 		},
 	}
 }
+
+// formatDataLimit renders a voucher's data usage limit, or "Unlimited"
+func formatDataLimit(v unifi.HotspotVoucher) string {
+	if v.IsDataUnlimited() {
+		return "Unlimited"
+	}
+	return fmt.Sprintf("%d MB", v.DataUsageLimitMB)
+}
+
+// formatRateLimit renders a voucher's download/upload rate limits as
+// "down/up Kbps", with "Unlimited" for a direction without a limit
+func formatRateLimit(v unifi.HotspotVoucher) string {
+	if v.IsRateUnlimited() {
+		return "Unlimited"
+	}
+	rate := func(kbps int) string {
+		if kbps <= 0 {
+			return "Unlimited"
+		}
+		return fmt.Sprintf("%d", kbps)
+	}
+	return fmt.Sprintf("%s/%s Kbps", rate(v.RxRateLimitKbps), rate(v.TxRateLimitKbps))
+}
+
+// formatRemainingTime renders a voucher's remaining access time to the
+// minute, or says why it has none
+func formatRemainingTime(v unifi.HotspotVoucher, now time.Time) string {
+	switch {
+	case v.IsExpired(now):
+		return "Expired"
+	case v.ActivatedAt == "":
+		return "Not activated"
+	}
+
+	remaining := v.RemainingTime(now)
+	if remaining == 0 {
+		return "Expired"
+	}
+	remaining = remaining.Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(remaining.Hours()), int(remaining.Minutes())%60)
+}
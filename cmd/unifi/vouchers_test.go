@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+func TestFormatVoucherLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		voucher  unifi.HotspotVoucher
+		wantData string
+		wantRate string
+	}{
+		{
+			name:     "zero limits are unlimited",
+			voucher:  unifi.HotspotVoucher{},
+			wantData: "Unlimited",
+			wantRate: "Unlimited",
+		},
+		{
+			name:     "all limits set",
+			voucher:  unifi.HotspotVoucher{DataUsageLimitMB: 1024, RxRateLimitKbps: 4096, TxRateLimitKbps: 1024},
+			wantData: "1024 MB",
+			wantRate: "4096/1024 Kbps",
+		},
+		{
+			name:     "download limit only",
+			voucher:  unifi.HotspotVoucher{RxRateLimitKbps: 2048},
+			wantData: "Unlimited",
+			wantRate: "2048/Unlimited Kbps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDataLimit(tt.voucher); got != tt.wantData {
+				t.Errorf("formatDataLimit() = %q, want %q", got, tt.wantData)
+			}
+			if got := formatRateLimit(tt.voucher); got != tt.wantRate {
+				t.Errorf("formatRateLimit() = %q, want %q", got, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestFormatRemainingTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		voucher unifi.HotspotVoucher
+		want    string
+	}{
+		{"not activated", unifi.HotspotVoucher{TimeLimitMinutes: 60}, "Not activated"},
+		{"time left", unifi.HotspotVoucher{ActivatedAt: "2024-01-01T11:00:00Z", TimeLimitMinutes: 150}, "1h 30m"},
+		{"used up", unifi.HotspotVoucher{ActivatedAt: "2024-01-01T10:00:00Z", TimeLimitMinutes: 60}, "Expired"},
+		{"flagged expired", unifi.HotspotVoucher{ActivatedAt: "2024-01-01T11:00:00Z", TimeLimitMinutes: 150, Expired: true}, "Expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatRemainingTime(tt.voucher, now); got != tt.want {
+				t.Errorf("formatRemainingTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPageSummary(t *testing.T) {
+	resp := unifi.ListHotspotVouchersResponse{
+		PaginatedResponse: unifi.PaginatedResponse{Offset: 50, Limit: 25, Count: 12, TotalCount: 62},
+	}
+
+	got := formatPageSummary(resp.Count, resp.TotalCount, resp.Offset, "vouchers")
+	if want := "Showing 12 of 62 vouchers (offset: 50)"; got != want {
+		t.Errorf("formatPageSummary() = %q, want %q", got, want)
+	}
+}
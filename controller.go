@@ -0,0 +1,24 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// systemCommand is a command for the controller application itself
+type systemCommand struct {
+	Command string `json:"cmd"`
+}
+
+// RestartController restarts the Network application on the controller.
+// Unlike a device restart this takes the API offline for everyone until the
+// application is back up, which usually takes a minute or two; poll
+// GetApplicationInfo to find out when it is.
+func (c *Client) RestartController(ctx context.Context) error {
+	if err := c.do(ctx, http.MethodPost, "/v1/system", &systemCommand{Command: "restart"}, nil); err != nil {
+		return fmt.Errorf("failed to restart controller: %w", err)
+	}
+
+	return nil
+}
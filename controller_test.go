@@ -0,0 +1,50 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClient_RestartController(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("request body", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, nil)
+
+		if err := client.RestartController(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if mock.request.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", mock.request.Method)
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/system" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+		var body map[string]interface{}
+		decodeRequestBody(t, mock.request, &body)
+		if len(body) != 1 || body["cmd"] != "restart" {
+			t.Errorf(`expected body {"cmd":"restart"}, got %v`, body)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(403, Error{Status: 403, StatusName: "Forbidden", Message: "Insufficient permissions"})
+
+		err := client.RestartController(ctx)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Status != 403 {
+			t.Errorf("expected API error with status 403, got %v", err)
+		}
+		if len(mock.requests) != 1 {
+			t.Errorf("expected a single attempt, got %d", len(mock.requests))
+		}
+	})
+}
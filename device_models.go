@@ -0,0 +1,112 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// DeviceModel describes a device model the controller supports
+type DeviceModel struct {
+	Code         string   `json:"model"`        // Model code reported by devices (e.g., U6PRO)
+	Name         string   `json:"name"`         // Display name (e.g., U6-Pro)
+	Type         string   `json:"type"`         // Device type (uap, usw, ugw, ...)
+	Capabilities []string `json:"capabilities"` // Supported features (e.g., wifi6, poe)
+}
+
+// HasCapability reports whether the model lists capability
+func (m DeviceModel) HasCapability(capability string) bool {
+	for _, c := range m.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// deviceModelCache holds the model list after the first successful fetch.
+// The list only changes with a controller upgrade, so it never expires, but
+// it is only served for the API key it was fetched with.
+type deviceModelCache struct {
+	fetching chan struct{} // Held while fetching so concurrent callers wait for one request
+	mu       sync.Mutex
+	models   []DeviceModel
+	apiKey   string // Key the models were fetched with
+}
+
+func newDeviceModelCache() *deviceModelCache {
+	return &deviceModelCache{fetching: make(chan struct{}, 1)}
+}
+
+// get returns the cached models if they were fetched with apiKey
+func (dc *deviceModelCache) get(apiKey string) ([]DeviceModel, bool) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	if dc.models == nil || dc.apiKey != apiKey {
+		return nil, false
+	}
+	return dc.models, true
+}
+
+// set caches models fetched with apiKey
+func (dc *deviceModelCache) set(apiKey string, models []DeviceModel) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.models = models
+	dc.apiKey = apiKey
+}
+
+// ListDeviceModels retrieves the device models the controller supports. The
+// result is cached on the client after the first successful call; clones
+// made with Clone share the cache unless they change the API key or headers.
+// Concurrent callers wait for a single request, but each stops waiting when
+// its own ctx is done.
+func (c *Client) ListDeviceModels(ctx context.Context) ([]DeviceModel, error) {
+	cached, err := c.deviceModelList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy so callers cannot modify the cached list
+	models := make([]DeviceModel, len(cached))
+	for i, m := range cached {
+		m.Capabilities = append([]string(nil), m.Capabilities...)
+		models[i] = m
+	}
+	return models, nil
+}
+
+// deviceModelList returns the cached model list, fetching it if needed
+func (c *Client) deviceModelList(ctx context.Context) ([]DeviceModel, error) {
+	apiKey := c.state.key()
+	if models, ok := c.deviceModels.get(apiKey); ok {
+		return models, nil
+	}
+
+	select {
+	case c.deviceModels.fetching <- struct{}{}:
+		defer func() { <-c.deviceModels.fetching }()
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to list device models: %w", ctx.Err())
+	}
+
+	// Another caller may have fetched the list while this one waited
+	if models, ok := c.deviceModels.get(apiKey); ok {
+		return models, nil
+	}
+
+	var response struct {
+		Data []DeviceModel `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v1/device-models", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list device models: %w", err)
+	}
+
+	models := response.Data
+	if models == nil {
+		models = []DeviceModel{}
+	}
+	c.deviceModels.set(apiKey, models)
+	return models, nil
+}
@@ -0,0 +1,179 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClient_ListDeviceModels(t *testing.T) {
+	ctx := context.Background()
+	body := `{"data":[
+		{"model":"U6PRO","name":"U6-Pro","type":"uap","capabilities":["wifi6","poe"]},
+		{"model":"US24P250","name":"USW-24-PoE","type":"usw","capabilities":["poe"]}
+	]}`
+
+	t.Run("parses response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, body)
+
+		models, err := client.ListDeviceModels(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := mock.request.URL.Path; !strings.HasSuffix(got, "/v1/device-models") {
+			t.Errorf("unexpected request path: %s", got)
+		}
+		want := []DeviceModel{
+			{Code: "U6PRO", Name: "U6-Pro", Type: "uap", Capabilities: []string{"wifi6", "poe"}},
+			{Code: "US24P250", Name: "USW-24-PoE", Type: "usw", Capabilities: []string{"poe"}},
+		}
+		if !reflect.DeepEqual(models, want) {
+			t.Errorf("expected models %+v, got %+v", want, models)
+		}
+		if !models[0].HasCapability("wifi6") || models[1].HasCapability("wifi6") {
+			t.Error("unexpected HasCapability result")
+		}
+	})
+
+	t.Run("cached after first fetch", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{mockRawResponse(200, body)}
+		mock.response = mockResponse(500, Error{Status: 500, Message: "should not be called"})
+
+		first, err := client.ListDeviceModels(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		first[0].Name = "modified"
+		first[0].Capabilities[0] = "modified"
+
+		second, err := client.ListDeviceModels(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error on cached call: %v", err)
+		}
+		if len(mock.requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.requests))
+		}
+		if second[0].Name != "U6-Pro" || second[0].Capabilities[0] != "wifi6" {
+			t.Errorf("expected cached list to be unaffected by caller changes, got %+v", second[0])
+		}
+
+		clone, err := client.Clone()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := clone.ListDeviceModels(ctx); err != nil {
+			t.Fatalf("unexpected error from clone: %v", err)
+		}
+		if len(mock.requests) != 1 {
+			t.Errorf("expected clone to share the cache, got %d requests", len(mock.requests))
+		}
+	})
+
+	t.Run("cache is per API key", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, body)
+
+		if _, err := client.ListDeviceModels(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		clone, err := client.Clone(WithAPIKey("other-key"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if clone.deviceModels == client.deviceModels {
+			t.Error("expected a clone with a different API key not to share the cache")
+		}
+
+		mock.response = mockRawResponse(200, body)
+		if err := client.SetAPIKey("rotated-key"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.ListDeviceModels(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.requests) != 2 {
+			t.Errorf("expected a refetch after the key changed, got %d requests", len(mock.requests))
+		}
+	})
+
+	t.Run("waiting caller honours its context", func(t *testing.T) {
+		transport := &blockingTransport{started: make(chan struct{}), release: make(chan struct{})}
+		client, err := NewClient(testBaseURL,
+			WithAPIKey("test-api-key"),
+			WithHTTPClient(&http.Client{Transport: transport}),
+			WithLogger(quietLogger()),
+		)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.ListDeviceModels(ctx)
+			done <- err
+		}()
+		<-transport.started
+
+		waiting, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := client.ListDeviceModels(waiting); !IsCanceled(err) {
+			t.Errorf("expected cancellation error while another fetch is in flight, got %v", err)
+		}
+
+		close(transport.release)
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error from first caller: %v", err)
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{mockResponse(503, Error{Status: 503, Message: "Service Unavailable"})}
+		mock.response = mockRawResponse(200, body)
+
+		if _, err := client.ListDeviceModels(ctx); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		models, err := client.ListDeviceModels(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(models) != 2 {
+			t.Errorf("expected 2 models, got %d", len(models))
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":null}`)
+
+		models, err := client.ListDeviceModels(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if models == nil || len(models) != 0 {
+			t.Errorf("expected empty non-nil slice, got %#v", models)
+		}
+	})
+}
+
+// blockingTransport answers with an empty model list once release is closed,
+// signalling started when the first request arrives
+type blockingTransport struct {
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (t *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.once.Do(func() { close(t.started) })
+	<-t.release
+	return mockRawResponse(200, `{"data":[]}`), nil
+}
@@ -2,9 +2,13 @@ package unifi
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // Device represents a UniFi network device
@@ -25,6 +29,57 @@ type Device struct {
 	State      int    `json:"state"`
 	LastUplink string `json:"last_uplink"`
 	UplinkMAC  string `json:"uplink"`
+
+	UpgradeToFirmware   string              `json:"upgrade_to_firmware"` // Firmware version available for upgrade, if reported
+	LEDOverride         string              `json:"led_override"`        // LED override mode (default, on, off)
+	LEDOverrideColor    string              `json:"led_override_color"`  // LED color override as a hex string
+	ManagementNetworkID string              `json:"mgmt_network_id"`     // Network (VLAN) used for device management
+	ManagementVLAN      int                 `json:"mgmt_vlan"`           // VLAN ID for device management, 0 for untagged; set with SetDeviceMgmtVLAN
+	ConfigNetwork       DeviceConfigNetwork `json:"config_network"`      // Management interface IP configuration
+}
+
+// DeviceConfigNetwork represents a device's management interface configuration
+type DeviceConfigNetwork struct {
+	Type    string `json:"type"`    // Addressing mode (dhcp, static)
+	IP      string `json:"ip"`      // Static IP address
+	Netmask string `json:"netmask"` // Static netmask
+	Gateway string `json:"gateway"` // Static gateway
+	DNS1    string `json:"dns1"`    // Primary DNS server
+	DNS2    string `json:"dns2"`    // Secondary DNS server
+}
+
+// NeedsUpgrade reports whether the controller has a newer firmware available for the device
+func (d Device) NeedsUpgrade() bool {
+	return d.Upgradable
+}
+
+// LastSeenTime returns when the controller last heard from the device, or
+// the zero time if it has not reported one
+func (d Device) LastSeenTime() time.Time {
+	if d.LastSeen == 0 {
+		return time.Time{}
+	}
+	return time.Unix(d.LastSeen, 0)
+}
+
+// Device statuses returned by Device.Status
+const (
+	DeviceStatusOnline   = "online"
+	DeviceStatusOffline  = "offline"
+	DeviceStatusDisabled = "disabled"
+)
+
+// Status summarizes the device's state as DeviceStatusOnline,
+// DeviceStatusOffline or DeviceStatusDisabled
+func (d Device) Status() string {
+	switch {
+	case d.Disabled:
+		return DeviceStatusDisabled
+	case d.State == 1:
+		return DeviceStatusOnline
+	default:
+		return DeviceStatusOffline
+	}
 }
 
 // DevicePortAction represents the action to perform on a device port
@@ -60,20 +115,102 @@ type DeviceStatistics struct {
 	BytesR      int64   `json:"bytes-r"`    // Total bytes in last interval
 	RxBytesR    int64   `json:"rx_bytes-r"` // Received bytes in last interval
 	TxBytesR    int64   `json:"tx_bytes-r"` // Transmitted bytes in last interval
-	CPU         float64 `json:"cpu"`        // CPU usage percentage
-	Memory      float64 `json:"mem"`        // Memory usage percentage
+	CPU         float64 `json:"cpu"`        // CPU usage, as a percentage or fraction depending on controller version; see CPUPercent
+	Memory      float64 `json:"mem"`        // Memory usage, as a percentage or fraction depending on controller version; see MemoryPercent
 	SystemStats struct {
 		Temperature float64 `json:"temperature"` // Device temperature
 		FanLevel    int     `json:"fan_level"`   // Fan level (if applicable)
+		// Load averages, reported by gateways as strings or numbers; see LoadAverages
+		LoadAvg1  json.Number `json:"loadavg_1,omitempty"`
+		LoadAvg5  json.Number `json:"loadavg_5,omitempty"`
+		LoadAvg15 json.Number `json:"loadavg_15,omitempty"`
 	} `json:"system-stats"`
-	Uptime int64 `json:"uptime"` // Device uptime in seconds
+	Uptime    int64      `json:"uptime"`     // Device uptime in seconds
+	PortTable []PortStat `json:"port_table"` // Per-port statistics (switches and gateways)
+}
+
+// CPUPercent returns CPU usage normalized to the 0-100 range
+func (s DeviceStatistics) CPUPercent() float64 {
+	return normalizePercent(s.CPU)
+}
+
+// MemoryPercent returns memory usage normalized to the 0-100 range
+func (s DeviceStatistics) MemoryPercent() float64 {
+	return normalizePercent(s.Memory)
+}
+
+// LoadAverages returns the 1, 5 and 15 minute load averages. ok is false
+// when the device does not report them, which is the case for most devices
+// other than gateways.
+func (s DeviceStatistics) LoadAverages() (one, five, fifteen float64, ok bool) {
+	var err error
+	if one, err = s.SystemStats.LoadAvg1.Float64(); err != nil {
+		return 0, 0, 0, false
+	}
+	if five, err = s.SystemStats.LoadAvg5.Float64(); err != nil {
+		return 0, 0, 0, false
+	}
+	if fifteen, err = s.SystemStats.LoadAvg15.Float64(); err != nil {
+		return 0, 0, 0, false
+	}
+	return one, five, fifteen, true
+}
+
+// normalizePercent converts a usage value to a percentage. Some controller
+// versions report usage as a fraction (0-1) and others as a percentage (0-100);
+// values at or below 1 are treated as fractions, which misreads a true
+// percentage of 1% or less but is the only reliable signal available.
+func normalizePercent(v float64) float64 {
+	if v > 0 && v <= 1 {
+		return v * 100
+	}
+	return v
+}
+
+// PortStat represents the traffic counters for a single device port
+type PortStat struct {
+	PortIDX   int     `json:"port_idx"`   // Port index number
+	Name      string  `json:"name"`       // Port name
+	Up        bool    `json:"up"`         // Whether the port link is up
+	Speed     int     `json:"speed"`      // Link speed in Mbps
+	RxBytes   int64   `json:"rx_bytes"`   // Received bytes
+	TxBytes   int64   `json:"tx_bytes"`   // Transmitted bytes
+	RxPackets int64   `json:"rx_packets"` // Received packets
+	TxPackets int64   `json:"tx_packets"` // Transmitted packets
+	RxErrors  int64   `json:"rx_errors"`  // Receive errors
+	TxErrors  int64   `json:"tx_errors"`  // Transmit errors
+	PoEPower  float64 `json:"poe_power"`  // PoE power draw in watts (if applicable)
+}
+
+// Device types accepted by the ListDevicesParams Type filter
+const (
+	DeviceTypeAccessPoint    = "uap" // Access point
+	DeviceTypeSwitch         = "usw" // Switch
+	DeviceTypeGateway        = "ugw" // Security gateway
+	DeviceTypeDreamMachine   = "udm" // Dream Machine
+	DeviceTypeNextGenGateway = "uxg" // Next-generation gateway
+	DeviceTypeBuildingBridge = "ubb" // Building-to-building bridge
+)
+
+// knownDeviceTypes is the set of device types the controller reports
+var knownDeviceTypes = map[string]bool{
+	DeviceTypeAccessPoint:    true,
+	DeviceTypeSwitch:         true,
+	DeviceTypeGateway:        true,
+	DeviceTypeDreamMachine:   true,
+	DeviceTypeNextGenGateway: true,
+	DeviceTypeBuildingBridge: true,
 }
 
 // ListDevicesParams contains parameters for listing devices
 type ListDevicesParams struct {
 	Offset int    `json:"offset,omitempty"`
 	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"` // Next page token from a cursor-paginated response
 	Type   string `json:"type,omitempty"`
+	// Strict rejects a Type that is not a known device type instead of
+	// sending it; otherwise an unknown Type that matches nothing is logged
+	Strict bool `json:"-"`
 }
 
 // ListDevicesResponse represents the response from listing devices
@@ -84,58 +221,102 @@ type ListDevicesResponse struct {
 
 // ListDevices retrieves a paginated list of devices for a site
 func (c *Client) ListDevices(ctx context.Context, siteID string, params *ListDevicesParams) (*ListDevicesResponse, error) {
-	urlPath := fmt.Sprintf("/v1/sites/%s/devices", siteID)
+	if params != nil && params.Strict && params.Type != "" && !knownDeviceTypes[params.Type] {
+		return nil, fmt.Errorf("unknown device type %q", params.Type)
+	}
 
-	if params != nil {
-		query := url.Values{}
-		if params.Offset > 0 {
-			query.Set("offset", fmt.Sprint(params.Offset))
-		}
-		if params.Limit > 0 {
-			query.Set("limit", fmt.Sprint(params.Limit))
-		}
-		if params.Type != "" {
-			query.Set("type", params.Type)
-		}
-		if len(query) > 0 {
-			urlPath += "?" + query.Encode()
-		}
+	query, err := buildQuery(params, c.maxPageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.listDevices(ctx, siteID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// The controller silently returns nothing for a misspelled type
+	if params != nil && params.Type != "" && !knownDeviceTypes[params.Type] && len(resp.Data) == 0 {
+		c.logger.Warn("Device type filter matched no devices and is not a known type",
+			"type", params.Type)
+	}
+
+	return resp, nil
+}
+
+// listDevices fetches one page of devices using pre-built query parameters
+func (c *Client) listDevices(ctx context.Context, siteID string, query url.Values) (*ListDevicesResponse, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices", url.PathEscape(siteID))
+
+	if len(query) > 0 {
+		urlPath += "?" + query.Encode()
 	}
 
+	apiKey := c.state.key()
+	if cached, ok := c.listCache.get(urlPath, apiKey, c.clock.Now()); ok {
+		return cached.(*ListDevicesResponse).clone(), nil
+	}
+
+	gen := c.listCache.generation(urlPath)
 	var response ListDevicesResponse
 	err := c.do(ctx, http.MethodGet, urlPath, nil, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list devices: %w", err)
 	}
 
+	c.listCache.set(urlPath, apiKey, response.clone(), c.clock.Now(), gen)
 	return &response, nil
 }
 
+// clone returns a copy of the response whose Data can be modified without
+// affecting the original
+func (r *ListDevicesResponse) clone() *ListDevicesResponse {
+	clone := *r
+	clone.Data = append([]Device(nil), r.Data...)
+	return &clone
+}
+
 // GetDevice retrieves a specific device by ID
 func (c *Client) GetDevice(ctx context.Context, siteID, deviceID string) (*Device, error) {
-	var response struct {
-		Data []Device `json:"data"`
+	device, _, err := c.GetDeviceRaw(ctx, siteID, deviceID)
+	return device, err
+}
+
+// GetDeviceRaw retrieves a specific device by ID along with the raw JSON the
+// controller returned for it, which is useful for spotting schema drift
+func (c *Client) GetDeviceRaw(ctx context.Context, siteID, deviceID string) (*Device, json.RawMessage, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, nil, err
 	}
 
-	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/sites/%s/devices/%s", siteID, deviceID), nil, &response)
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s", url.PathEscape(siteID), url.PathEscape(deviceID))
+	device, raw, err := getFirst[Device](ctx, c, urlPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get device: %w", err)
+		return nil, nil, fmt.Errorf("failed to get device: %w", err)
 	}
 
-	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("device not found: %s", deviceID)
+	if device == nil {
+		return nil, nil, &NotFoundError{Resource: "device", ID: deviceID}
 	}
 
-	return &response.Data[0], nil
+	return device, raw, nil
 }
 
 // ExecutePortAction performs an action on a specific port of a device
 func (c *Client) ExecutePortAction(ctx context.Context, siteID, deviceID string, action *DevicePortAction) error {
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+
 	if action == nil {
 		return fmt.Errorf("action cannot be nil")
 	}
 
-	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s/port/%s", siteID, deviceID, action.PortID)
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s/port/%s", url.PathEscape(siteID), url.PathEscape(deviceID), url.PathEscape(action.PortID))
 	err := c.do(ctx, http.MethodPost, urlPath, action, nil)
 	if err != nil {
 		return fmt.Errorf("failed to execute port action: %w", err)
@@ -146,11 +327,15 @@ func (c *Client) ExecutePortAction(ctx context.Context, siteID, deviceID string,
 
 // ExecuteDeviceAction performs an action on a device
 func (c *Client) ExecuteDeviceAction(ctx context.Context, siteID, deviceID string, action *DeviceAction) error {
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+
 	if action == nil {
 		return fmt.Errorf("action cannot be nil")
 	}
 
-	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s", siteID, deviceID)
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s", url.PathEscape(siteID), url.PathEscape(deviceID))
 	err := c.do(ctx, http.MethodPost, urlPath, action, nil)
 	if err != nil {
 		return fmt.Errorf("failed to execute device action: %w", err)
@@ -159,13 +344,149 @@ func (c *Client) ExecuteDeviceAction(ctx context.Context, siteID, deviceID strin
 	return nil
 }
 
+// DisconnectClientFromDevice forces a wireless client off one access point.
+// The client is free to reconnect, to that AP or another, so this is useful
+// for testing band steering and roaming rather than for blocking; use
+// BlockClients to keep a client off the site. clientMAC may use any common
+// notation.
+func (c *Client) DisconnectClientFromDevice(ctx context.Context, siteID, deviceID, clientMAC string) error {
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+	if deviceID == "" {
+		return fmt.Errorf("deviceId is required")
+	}
+	mac, err := NormalizeMAC(clientMAC)
+	if err != nil {
+		return err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s", url.PathEscape(siteID), url.PathEscape(deviceID))
+	command := &ClientCommand{Cmd: "kick-sta", MAC: mac}
+	if err := c.do(ctx, http.MethodPost, urlPath, command, nil); err != nil {
+		return fmt.Errorf("failed to disconnect client from device: %w", err)
+	}
+
+	return nil
+}
+
+// DeviceUpdate represents a partial update to a device's configuration.
+// Nil fields are left unchanged by the controller.
+type DeviceUpdate struct {
+	ManagementVLAN *int `json:"mgmt_vlan,omitempty"` // VLAN ID for device management, 0 for untagged
+}
+
+// maxVLAN is the highest valid 802.1Q VLAN ID
+const maxVLAN = 4095
+
+// updateDevice PATCHes a device's configuration
+func (c *Client) updateDevice(ctx context.Context, siteID, deviceID string, update *DeviceUpdate) error {
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+	if deviceID == "" {
+		return fmt.Errorf("deviceId is required")
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s", url.PathEscape(siteID), url.PathEscape(deviceID))
+	return c.do(ctx, http.MethodPatch, urlPath, update, nil)
+}
+
+// SetDeviceMgmtVLAN moves a device's management interface to vlan, where 0
+// means untagged. The device re-provisions and may briefly drop off the
+// network, so make sure the VLAN is reachable from the controller first.
+func (c *Client) SetDeviceMgmtVLAN(ctx context.Context, siteID, deviceID string, vlan int) error {
+	if vlan < 0 || vlan > maxVLAN {
+		return fmt.Errorf("vlan must be between 0 and %d, got %d", maxVLAN, vlan)
+	}
+
+	if err := c.updateDevice(ctx, siteID, deviceID, &DeviceUpdate{ManagementVLAN: &vlan}); err != nil {
+		return fmt.Errorf("failed to set management VLAN: %w", err)
+	}
+
+	return nil
+}
+
+// AdoptDevice adopts a device pending adoption and reports whether it sent
+// the adopt request. A device that is already adopted is left alone and
+// false is returned with a nil error, so provisioning scripts can call it
+// repeatedly.
+func (c *Client) AdoptDevice(ctx context.Context, siteID, deviceID string) (bool, error) {
+	device, err := c.GetDevice(ctx, siteID, deviceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to adopt device: %w", err)
+	}
+	if device.Adopted {
+		c.logger.Debug("Device already adopted", "site_id", siteID, "device_id", deviceID)
+		return false, nil
+	}
+
+	if err := c.ExecuteDeviceAction(ctx, siteID, deviceID, &DeviceAction{Action: "adopt"}); err != nil {
+		return false, fmt.Errorf("failed to adopt device: %w", err)
+	}
+
+	return true, nil
+}
+
+// AdoptAllPending adopts every device on a site that is waiting to be
+// adopted and returns how many were adopted. Requests are issued
+// concurrently, bounded by the client's concurrency limit; failures for
+// individual devices are joined into the returned error and do not stop the
+// others.
+func (c *Client) AdoptAllPending(ctx context.Context, siteID string) (int, error) {
+	devices, err := c.ListAllDevices(ctx, siteID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to adopt pending devices: %w", err)
+	}
+
+	var pending []Device
+	for _, device := range devices {
+		if !device.Adopted {
+			pending = append(pending, device)
+		}
+	}
+
+	errs := make([]error, len(pending))
+	succeeded := make([]bool, len(pending))
+	err = c.fanOut(ctx, len(pending), func(ctx context.Context, i int) error {
+		action := &DeviceAction{Action: "adopt"}
+		if err := c.ExecuteDeviceAction(ctx, siteID, pending[i].ID, action); err != nil {
+			errs[i] = fmt.Errorf("%s: %w", pending[i].ID, err)
+			// Keep going so one failed adoption doesn't stop the rest
+			return nil
+		}
+		succeeded[i] = true
+		return nil
+	})
+
+	adopted := 0
+	for _, ok := range succeeded {
+		if ok {
+			adopted++
+		}
+	}
+
+	if err != nil {
+		return adopted, fmt.Errorf("failed to adopt pending devices: %w", err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return adopted, fmt.Errorf("failed to adopt pending devices: %w", err)
+	}
+
+	return adopted, nil
+}
+
 // GetDeviceStatistics retrieves the latest statistics for a device
 func (c *Client) GetDeviceStatistics(ctx context.Context, siteID, deviceID string) (*DeviceStatistics, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
 	var response struct {
 		Data []DeviceStatistics `json:"data"`
 	}
 
-	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s/stats", siteID, deviceID)
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s/stats", url.PathEscape(siteID), url.PathEscape(deviceID))
 	err := c.do(ctx, http.MethodGet, urlPath, nil, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get device statistics: %w", err)
@@ -177,3 +498,161 @@ func (c *Client) GetDeviceStatistics(ctx context.Context, siteID, deviceID strin
 
 	return &response.Data[0], nil
 }
+
+// DownloadDeviceSupportInfo streams a device's support-info bundle. The caller
+// must close the returned reader.
+func (c *Client) DownloadDeviceSupportInfo(ctx context.Context, siteID, deviceID string) (io.ReadCloser, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+	if deviceID == "" {
+		return nil, fmt.Errorf("deviceId is required")
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s/support-info", url.PathEscape(siteID), url.PathEscape(deviceID))
+	body, err := c.stream(ctx, urlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download device support info: %w", err)
+	}
+
+	return body, nil
+}
+
+// GetDevices retrieves several devices by ID concurrently, bounded by the
+// client's concurrency limit. Results are returned in the same order as deviceIDs.
+func (c *Client) GetDevices(ctx context.Context, siteID string, deviceIDs []string) ([]*Device, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	devices := make([]*Device, len(deviceIDs))
+	err := c.fanOut(ctx, len(deviceIDs), func(ctx context.Context, i int) error {
+		device, err := c.GetDevice(ctx, siteID, deviceIDs[i])
+		if err != nil {
+			return err
+		}
+		devices[i] = device
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+// ListAllDevices retrieves every device for a site, following offset or cursor pagination
+func (c *Client) ListAllDevices(ctx context.Context, siteID string) ([]Device, error) {
+	return collect(c.IterDevices(ctx, siteID, nil))
+}
+
+// FindDeviceByMAC searches every site for a device with the given MAC address,
+// in any common notation, and returns the ID of the site it belongs to
+func (c *Client) FindDeviceByMAC(ctx context.Context, mac string) (string, *Device, error) {
+	want, err := NormalizeMAC(mac)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sites, err := c.ListAllSites(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find device: %w", err)
+	}
+
+	for _, site := range sites {
+		devices, err := c.ListAllDevices(ctx, site.ID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to find device: %w", err)
+		}
+		for i := range devices {
+			if got, err := NormalizeMAC(devices[i].MAC); err == nil && got == want {
+				return site.ID, &devices[i], nil
+			}
+		}
+	}
+
+	return "", nil, &NotFoundError{Resource: "device", ID: mac}
+}
+
+// OverheatingDevices fetches statistics for every device on a site and
+// returns those reporting a temperature above maxTempC. Devices without a
+// temperature sensor report zero and are never included. Statistics are
+// fetched concurrently, bounded by the client's concurrency limit.
+func (c *Client) OverheatingDevices(ctx context.Context, siteID string, maxTempC float64) ([]DeviceStatistics, error) {
+	devices, err := c.ListAllDevices(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find overheating devices: %w", err)
+	}
+
+	stats := make([]*DeviceStatistics, len(devices))
+	err = c.fanOut(ctx, len(devices), func(ctx context.Context, i int) error {
+		s, err := c.GetDeviceStatistics(ctx, siteID, devices[i].ID)
+		if err != nil {
+			return err
+		}
+		// The stats endpoint may omit identifiers, so fill them from the device
+		if s.ID == "" {
+			s.ID = devices[i].ID
+		}
+		if s.MAC == "" {
+			s.MAC = devices[i].MAC
+		}
+		stats[i] = s
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find overheating devices: %w", err)
+	}
+
+	hot := make([]DeviceStatistics, 0)
+	for _, s := range stats {
+		if s.SystemStats.Temperature != 0 && s.SystemStats.Temperature > maxTempC {
+			hot = append(hot, *s)
+		}
+	}
+
+	return hot, nil
+}
+
+// OutdatedDevices returns the devices on a site that have a firmware upgrade available
+func (c *Client) OutdatedDevices(ctx context.Context, siteID string) ([]Device, error) {
+	devices, err := c.ListAllDevices(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find outdated devices: %w", err)
+	}
+
+	outdated := make([]Device, 0)
+	for _, device := range devices {
+		if device.NeedsUpgrade() {
+			outdated = append(outdated, device)
+		}
+	}
+
+	return outdated, nil
+}
+
+// StaleDevices returns the devices on a site that the controller has not
+// heard from within threshold of the client's clock, which usually means
+// they are disconnected. Devices that have never reported a last-seen time,
+// such as ones still pending adoption, are not included.
+func (c *Client) StaleDevices(ctx context.Context, siteID string, threshold time.Duration) ([]Device, error) {
+	if threshold <= 0 {
+		return nil, fmt.Errorf("threshold must be positive")
+	}
+
+	devices, err := c.ListAllDevices(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale devices: %w", err)
+	}
+
+	cutoff := c.clock.Now().Add(-threshold)
+	stale := make([]Device, 0)
+	for _, device := range devices {
+		seen := device.LastSeenTime()
+		if !seen.IsZero() && seen.Before(cutoff) {
+			stale = append(stale, device)
+		}
+	}
+
+	return stale, nil
+}
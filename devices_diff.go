@@ -0,0 +1,102 @@
+package unifi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DeviceDiff describes how a set of devices changed between two snapshots
+type DeviceDiff struct {
+	Added   []Device       // Devices only present in the new snapshot
+	Removed []Device       // Devices only present in the old snapshot
+	Changed []DeviceChange // Devices present in both whose fields differ
+}
+
+// DeviceChange describes the field-level changes to a single device
+type DeviceChange struct {
+	ID      string        // Device ID
+	Old     Device        // Device as it appeared in the old snapshot
+	New     Device        // Device as it appears in the new snapshot
+	Changes []FieldChange // Fields that differ, in struct field order
+}
+
+// FieldChange describes a single changed field
+type FieldChange struct {
+	Field string // Go struct field name (e.g., "Name", "State")
+	Old   string // Formatted old value
+	New   string // Formatted new value
+}
+
+// IsEmpty reports whether the diff contains no changes
+func (d DeviceDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffDevices compares two device snapshots keyed by device ID. The result is
+// independent of input order: every slice is sorted by device ID.
+func DiffDevices(old, new []Device) DeviceDiff {
+	oldByID := make(map[string]Device, len(old))
+	for _, device := range old {
+		oldByID[device.ID] = device
+	}
+	newByID := make(map[string]Device, len(new))
+	for _, device := range new {
+		newByID[device.ID] = device
+	}
+
+	var diff DeviceDiff
+	for id, newDevice := range newByID {
+		oldDevice, ok := oldByID[id]
+		if !ok {
+			diff.Added = append(diff.Added, newDevice)
+			continue
+		}
+		if changes := diffDeviceFields(oldDevice, newDevice); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, DeviceChange{
+				ID:      id,
+				Old:     oldDevice,
+				New:     newDevice,
+				Changes: changes,
+			})
+		}
+	}
+	for id, oldDevice := range oldByID {
+		if _, ok := newByID[id]; !ok {
+			diff.Removed = append(diff.Removed, oldDevice)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].ID < diff.Added[j].ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].ID < diff.Changed[j].ID })
+
+	return diff
+}
+
+// diffDeviceFields returns the fields that differ between two versions of a device
+func diffDeviceFields(old, new Device) []FieldChange {
+	var changes []FieldChange
+
+	oldValue := reflect.ValueOf(old)
+	newValue := reflect.ValueOf(new)
+	deviceType := oldValue.Type()
+	for i := 0; i < deviceType.NumField(); i++ {
+		field := deviceType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		oldField := oldValue.Field(i).Interface()
+		newField := newValue.Field(i).Interface()
+		if reflect.DeepEqual(oldField, newField) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Field: field.Name,
+			Old:   fmt.Sprint(oldField),
+			New:   fmt.Sprint(newField),
+		})
+	}
+
+	return changes
+}
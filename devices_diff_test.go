@@ -0,0 +1,111 @@
+package unifi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffDevices(t *testing.T) {
+	ap := Device{ID: "ap1", Name: "Office AP", Model: "U6-Pro", State: 1}
+	sw := Device{ID: "sw1", Name: "Core Switch", Model: "USW-24", State: 1}
+	gw := Device{ID: "gw1", Name: "Gateway", Model: "UDM-Pro", State: 1}
+
+	renamedAP := ap
+	renamedAP.Name = "Lobby AP"
+
+	offlineSwitch := sw
+	offlineSwitch.State = 0
+
+	tests := []struct {
+		name        string
+		old         []Device
+		new         []Device
+		wantAdded   []string
+		wantRemoved []string
+		wantChanged map[string][]FieldChange
+	}{
+		{
+			name: "no changes",
+			old:  []Device{ap, sw},
+			new:  []Device{sw, ap},
+		},
+		{
+			name:      "addition",
+			old:       []Device{ap},
+			new:       []Device{ap, sw, gw},
+			wantAdded: []string{"gw1", "sw1"},
+		},
+		{
+			name:        "removal",
+			old:         []Device{ap, sw, gw},
+			new:         []Device{gw},
+			wantRemoved: []string{"ap1", "sw1"},
+		},
+		{
+			name: "name change",
+			old:  []Device{ap, sw},
+			new:  []Device{renamedAP, sw},
+			wantChanged: map[string][]FieldChange{
+				"ap1": {{Field: "Name", Old: "Office AP", New: "Lobby AP"}},
+			},
+		},
+		{
+			name: "state flip",
+			old:  []Device{sw},
+			new:  []Device{offlineSwitch},
+			wantChanged: map[string][]FieldChange{
+				"sw1": {{Field: "State", Old: "1", New: "0"}},
+			},
+		},
+		{
+			name:        "mixed and order independent",
+			old:         []Device{sw, ap},
+			new:         []Device{gw, renamedAP},
+			wantAdded:   []string{"gw1"},
+			wantRemoved: []string{"sw1"},
+			wantChanged: map[string][]FieldChange{
+				"ap1": {{Field: "Name", Old: "Office AP", New: "Lobby AP"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffDevices(tt.old, tt.new)
+
+			if got := deviceIDList(diff.Added); !reflect.DeepEqual(got, tt.wantAdded) {
+				t.Errorf("expected added %v, got %v", tt.wantAdded, got)
+			}
+			if got := deviceIDList(diff.Removed); !reflect.DeepEqual(got, tt.wantRemoved) {
+				t.Errorf("expected removed %v, got %v", tt.wantRemoved, got)
+			}
+
+			if len(diff.Changed) != len(tt.wantChanged) {
+				t.Fatalf("expected %d changed devices, got %d", len(tt.wantChanged), len(diff.Changed))
+			}
+			for _, change := range diff.Changed {
+				want, ok := tt.wantChanged[change.ID]
+				if !ok {
+					t.Errorf("unexpected change for device %s", change.ID)
+					continue
+				}
+				if !reflect.DeepEqual(change.Changes, want) {
+					t.Errorf("expected changes %v for device %s, got %v", want, change.ID, change.Changes)
+				}
+			}
+
+			wantEmpty := len(tt.wantAdded) == 0 && len(tt.wantRemoved) == 0 && len(tt.wantChanged) == 0
+			if diff.IsEmpty() != wantEmpty {
+				t.Errorf("expected IsEmpty() = %v, got %v", wantEmpty, diff.IsEmpty())
+			}
+		})
+	}
+}
+
+func deviceIDList(devices []Device) []string {
+	var ids []string
+	for _, device := range devices {
+		ids = append(ids, device.ID)
+	}
+	return ids
+}
@@ -1,9 +1,18 @@
 package unifi
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClient_ListDevices(t *testing.T) {
@@ -284,15 +293,10 @@ func TestClient_GetDeviceStatistics(t *testing.T) {
 			TxRate:  75.2,
 			CPU:     25.5,
 			Memory:  45.2,
-			SystemStats: struct {
-				Temperature float64 `json:"temperature"`
-				FanLevel    int     `json:"fan_level"`
-			}{
-				Temperature: 45.5,
-				FanLevel:    2,
-			},
-			Uptime: 3600,
+			Uptime:  3600,
 		}
+		expectedStats.SystemStats.Temperature = 45.5
+		expectedStats.SystemStats.FanLevel = 2
 
 		mock.response = mockResponse(200, struct {
 			Data []DeviceStatistics `json:"data"`
@@ -322,6 +326,49 @@ func TestClient_GetDeviceStatistics(t *testing.T) {
 		}
 	})
 
+	t.Run("switch with port table", func(t *testing.T) {
+		client, mock := newTestClient(t, baseURL)
+
+		mock.response = mockRawResponse(200, `{"data":[{
+			"_id": "abc123",
+			"mac": "00:11:22:33:44:55",
+			"port_table": [
+				{"port_idx": 1, "name": "Port 1", "up": true, "speed": 1000, "rx_bytes": 1000, "tx_bytes": 2000, "rx_packets": 10, "tx_packets": 20, "rx_errors": 0, "tx_errors": 1, "poe_power": 4.5},
+				{"port_idx": 2, "name": "Port 2", "up": false, "rx_bytes": 0, "tx_bytes": 0},
+				{"port_idx": 3, "name": "Uplink", "up": true, "speed": 10000, "rx_bytes": 5000, "tx_bytes": 6000, "rx_errors": 3}
+			]
+		}]}`)
+
+		result, err := client.GetDeviceStatistics(ctx, siteID, deviceID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.PortTable) != 3 {
+			t.Fatalf("expected 3 ports, got %d", len(result.PortTable))
+		}
+
+		port := result.PortTable[0]
+		if port.PortIDX != 1 {
+			t.Errorf("expected port index 1, got %d", port.PortIDX)
+		}
+		if port.RxBytes != 1000 || port.TxBytes != 2000 {
+			t.Errorf("expected rx/tx bytes 1000/2000, got %d/%d", port.RxBytes, port.TxBytes)
+		}
+		if port.TxErrors != 1 {
+			t.Errorf("expected 1 tx error, got %d", port.TxErrors)
+		}
+		if port.PoEPower != 4.5 {
+			t.Errorf("expected PoE power 4.50, got %.2f", port.PoEPower)
+		}
+		if result.PortTable[1].Up {
+			t.Error("expected port 2 to be down")
+		}
+		if result.PortTable[2].RxErrors != 3 {
+			t.Errorf("expected 3 rx errors on uplink, got %d", result.PortTable[2].RxErrors)
+		}
+	})
+
 	t.Run("no statistics found", func(t *testing.T) {
 		client, mock := newTestClient(t, baseURL)
 
@@ -360,3 +407,968 @@ func TestClient_GetDeviceStatistics(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_GetDeviceRaw(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("raw message round-trips to typed value", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockRawResponse(200, `{"data":[{
+			"_id": "abc123",
+			"mac": "00:11:22:33:44:55",
+			"model": "U6-Pro",
+			"type": "uap",
+			"name": "test-device",
+			"state": 1,
+			"new_field": {"nested": true}
+		}]}`)
+
+		device, raw, err := client.GetDeviceRaw(ctx, testSiteID, "abc123")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var decoded Device
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			t.Fatalf("failed to decode raw message: %v", err)
+		}
+		if decoded != *device {
+			t.Errorf("expected raw message to decode to %+v, got %+v", *device, decoded)
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			t.Fatalf("failed to decode raw message: %v", err)
+		}
+		if _, ok := fields["new_field"]; !ok {
+			t.Error("expected raw message to retain fields unknown to Device")
+		}
+	})
+
+	t.Run("device not found", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockRawResponse(200, `{"data":[]}`)
+
+		_, raw, err := client.GetDeviceRaw(ctx, testSiteID, "nonexistent")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if raw != nil {
+			t.Errorf("expected nil raw message, got %s", raw)
+		}
+	})
+}
+
+func TestClient_ListDevices_Limit(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit string
+		wantErr   bool
+	}{
+		{"max sentinel", LimitMax, "200", false},
+		{"explicit limit", 50, "50", false},
+		{"controller default", 0, "", false},
+		{"over maximum", 201, "", true},
+		{"below sentinel", -5, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := newTestClient(t, testBaseURL)
+			mock.response = mockResponse(200, ListDevicesResponse{})
+
+			_, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Limit: tt.limit})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got := mock.request.URL.Query().Get("limit"); got != tt.wantLimit {
+				t.Errorf("expected limit %q, got %q", tt.wantLimit, got)
+			}
+		})
+	}
+}
+
+func TestClient_StaleDevices(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("mixed last seen times", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		clock := newFakeClock()
+		clock.now = now
+		client.clock = clock
+
+		mock.response = mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Count: 5, TotalCount: 5},
+			Data: []Device{
+				{ID: "fresh", LastSeen: now.Add(-time.Minute).Unix()},
+				{ID: "boundary", LastSeen: now.Add(-time.Hour).Unix()},
+				{ID: "stale", LastSeen: now.Add(-2 * time.Hour).Unix()},
+				{ID: "pending", LastSeen: 0},
+				{ID: "ancient", LastSeen: now.AddDate(0, 0, -30).Unix()},
+			},
+		})
+
+		stale, err := client.StaleDevices(ctx, testSiteID, time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := deviceIDList(stale); !reflect.DeepEqual(got, []string{"stale", "ancient"}) {
+			t.Errorf("expected stale devices [stale ancient], got %v", got)
+		}
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		for _, threshold := range []time.Duration{0, -time.Hour} {
+			if _, err := client.StaleDevices(ctx, testSiteID, threshold); err == nil {
+				t.Errorf("expected error for threshold %v, got nil", threshold)
+			}
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+}
+
+func TestDevice_LastSeenTime(t *testing.T) {
+	if got := (Device{}).LastSeenTime(); !got.IsZero() {
+		t.Errorf("expected zero time, got %v", got)
+	}
+	if got, want := (Device{LastSeen: 1700000000}).LastSeenTime(), time.Unix(1700000000, 0); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestDevice_Status(t *testing.T) {
+	tests := []struct {
+		device Device
+		want   string
+	}{
+		{Device{State: 1}, DeviceStatusOnline},
+		{Device{State: 0}, DeviceStatusOffline},
+		{Device{State: 5}, DeviceStatusOffline},
+		{Device{State: 1, Disabled: true}, DeviceStatusDisabled},
+	}
+	for _, tt := range tests {
+		if got := tt.device.Status(); got != tt.want {
+			t.Errorf("Status() of %+v = %q, want %q", tt.device, got, tt.want)
+		}
+	}
+}
+
+func TestClient_OutdatedDevices(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("mixed device set", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Count: 4, TotalCount: 4, Limit: 200},
+			Data: []Device{
+				{ID: "ap1", Version: "6.5.28", Upgradable: true},
+				{ID: "ap2", Version: "6.6.55", Upgradable: false},
+				{ID: "sw1", Version: "6.5.59", Upgradable: true},
+				{ID: "gw1", Version: "3.2.9", Upgradable: false},
+			},
+		})
+
+		outdated, err := client.OutdatedDevices(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := deviceIDList(outdated); len(got) != 2 || got[0] != "ap1" || got[1] != "sw1" {
+			t.Errorf("expected outdated devices [ap1 sw1], got %v", got)
+		}
+		if got := mock.request.URL.Query().Get("limit"); got != "200" {
+			t.Errorf("expected devices to be listed with limit=200, got %q", got)
+		}
+	})
+
+	t.Run("none upgradable", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Count: 2, TotalCount: 2},
+			Data: []Device{
+				{ID: "ap1"},
+				{ID: "sw1"},
+			},
+		})
+
+		outdated, err := client.OutdatedDevices(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if outdated == nil || len(outdated) != 0 {
+			t.Errorf("expected an empty, non-nil slice, got %v", outdated)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(404, Error{
+			Status:     404,
+			StatusName: "Not Found",
+			Message:    "Site not found",
+		})
+
+		_, err := client.OutdatedDevices(ctx, "nonexistent")
+		assertErrorResponse(t, err, 404, "Site not found")
+	})
+}
+
+func TestClient_ListAllDevices(t *testing.T) {
+	client, mock := newTestClient(t, testBaseURL)
+
+	mock.responses = []*http.Response{
+		mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Offset: 0, Count: 2, TotalCount: 3},
+			Data:              []Device{{ID: "a"}, {ID: "b"}},
+		}),
+		mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Offset: 2, Count: 1, TotalCount: 3},
+			Data:              []Device{{ID: "c"}},
+		}),
+	}
+
+	devices, err := client.ListAllDevices(context.Background(), testSiteID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := deviceIDList(devices); len(got) != 3 || got[2] != "c" {
+		t.Errorf("expected devices [a b c], got %v", got)
+	}
+	if len(mock.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+	}
+	if got := mock.requests[1].URL.Query().Get("offset"); got != "2" {
+		t.Errorf("expected second page offset 2, got %q", got)
+	}
+}
+
+func TestClient_GetDevice_ConfigState(t *testing.T) {
+	client, mock := newTestClient(t, testBaseURL)
+
+	mock.response = mockRawResponse(200, `{"data":[{
+		"_id": "abc123",
+		"name": "Office AP",
+		"led_override": "off",
+		"led_override_color": "#0000ff",
+		"mgmt_network_id": "net-mgmt",
+		"config_network": {
+			"type": "static",
+			"ip": "10.0.10.5",
+			"netmask": "255.255.255.0",
+			"gateway": "10.0.10.1",
+			"dns1": "1.1.1.1"
+		}
+	}]}`)
+
+	device, err := client.GetDevice(context.Background(), testSiteID, "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if device.LEDOverride != "off" {
+		t.Errorf("expected LED override %q, got %q", "off", device.LEDOverride)
+	}
+	if device.LEDOverrideColor != "#0000ff" {
+		t.Errorf("expected LED color %q, got %q", "#0000ff", device.LEDOverrideColor)
+	}
+	if device.ManagementNetworkID != "net-mgmt" {
+		t.Errorf("expected management network %q, got %q", "net-mgmt", device.ManagementNetworkID)
+	}
+	want := DeviceConfigNetwork{
+		Type:    "static",
+		IP:      "10.0.10.5",
+		Netmask: "255.255.255.0",
+		Gateway: "10.0.10.1",
+		DNS1:    "1.1.1.1",
+	}
+	if device.ConfigNetwork != want {
+		t.Errorf("expected config network %+v, got %+v", want, device.ConfigNetwork)
+	}
+}
+
+func TestDeviceStatistics_Percent(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		want  float64
+	}{
+		{"fraction", 0.45, 45.0},
+		{"percentage", 45.0, 45.0},
+		{"zero", 0, 0},
+		{"full fraction", 1, 100},
+		{"full percentage", 100, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := DeviceStatistics{CPU: tt.value, Memory: tt.value}
+			if got := stats.CPUPercent(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CPUPercent() = %v, want %v", got, tt.want)
+			}
+			if got := stats.MemoryPercent(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("MemoryPercent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceStatistics_LoadAverages(t *testing.T) {
+	t.Run("gateway stats", func(t *testing.T) {
+		var stats DeviceStatistics
+		err := json.Unmarshal([]byte(`{
+			"_id": "gw1",
+			"cpu": 12.5,
+			"system-stats": {
+				"temperature": 51.0,
+				"loadavg_1": "0.42",
+				"loadavg_5": "0.35",
+				"loadavg_15": 0.3
+			}
+		}`), &stats)
+		if err != nil {
+			t.Fatalf("failed to unmarshal stats: %v", err)
+		}
+
+		one, five, fifteen, ok := stats.LoadAverages()
+		if !ok {
+			t.Fatal("expected load averages to be reported")
+		}
+		if one != 0.42 || five != 0.35 || fifteen != 0.3 {
+			t.Errorf("expected load 0.42/0.35/0.3, got %v/%v/%v", one, five, fifteen)
+		}
+		if stats.SystemStats.Temperature != 51.0 {
+			t.Errorf("expected temperature 51.0, got %v", stats.SystemStats.Temperature)
+		}
+	})
+
+	t.Run("not reported", func(t *testing.T) {
+		var stats DeviceStatistics
+		if err := json.Unmarshal([]byte(`{"_id": "ap1", "system-stats": {"temperature": 40}}`), &stats); err != nil {
+			t.Fatalf("failed to unmarshal stats: %v", err)
+		}
+
+		if _, _, _, ok := stats.LoadAverages(); ok {
+			t.Error("expected no load averages")
+		}
+		out, err := json.Marshal(stats)
+		if err != nil {
+			t.Fatalf("failed to marshal stats: %v", err)
+		}
+		if bytes.Contains(out, []byte("loadavg")) {
+			t.Errorf("expected load averages to be omitted, got %s", out)
+		}
+	})
+}
+
+func TestClient_ListDevices_TypeFilter(t *testing.T) {
+	ctx := context.Background()
+
+	newLoggedClient := func(t *testing.T) (*Client, *mockTransport, *bytes.Buffer) {
+		client, mock := newTestClient(t, testBaseURL)
+		var logs bytes.Buffer
+		client.logger = slog.New(slog.NewTextHandler(&logs, nil))
+		return client, mock, &logs
+	}
+
+	t.Run("valid type passes through", func(t *testing.T) {
+		client, mock, logs := newLoggedClient(t)
+		mock.response = mockResponse(200, ListDevicesResponse{})
+
+		_, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Type: DeviceTypeAccessPoint, Strict: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := mock.request.URL.Query().Get("type"); got != "uap" {
+			t.Errorf("expected type=uap, got %q", got)
+		}
+		if logs.Len() != 0 {
+			t.Errorf("expected no warning for a known type, got %s", logs.String())
+		}
+	})
+
+	t.Run("unknown type with no results logs a warning", func(t *testing.T) {
+		client, mock, logs := newLoggedClient(t)
+		mock.response = mockResponse(200, ListDevicesResponse{})
+
+		_, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Type: "uapp"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "type=uapp") {
+			t.Errorf("expected warning mentioning the type, got %q", logs.String())
+		}
+	})
+
+	t.Run("unknown type with results does not warn", func(t *testing.T) {
+		client, mock, logs := newLoggedClient(t)
+		mock.response = mockResponse(200, ListDevicesResponse{Data: []Device{{ID: "device1"}}})
+
+		if _, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Type: "uph"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if logs.Len() != 0 {
+			t.Errorf("expected no warning, got %s", logs.String())
+		}
+	})
+
+	t.Run("strict rejects unknown type", func(t *testing.T) {
+		client, mock, _ := newLoggedClient(t)
+
+		_, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Type: "uapp", Strict: true})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+}
+
+func TestClient_FindDeviceByMAC(t *testing.T) {
+	ctx := context.Background()
+
+	sitesPage := mockResponse(200, ListSitesResponse{
+		Count:      2,
+		TotalCount: 2,
+		Data:       []Site{{ID: "site-a", Name: "Office"}, {ID: "site-b", Name: "Warehouse"}},
+	})
+	siteADevices := func() *http.Response {
+		return mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Count: 1, TotalCount: 1},
+			Data:              []Device{{ID: "a1", MAC: "00:11:22:33:44:55"}},
+		})
+	}
+	siteBDevices := func() *http.Response {
+		return mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Count: 2, TotalCount: 2},
+			Data: []Device{
+				{ID: "b1", MAC: "66:77:88:99:aa:bb"},
+				{ID: "b2", MAC: "aa:bb:cc:dd:ee:ff"},
+			},
+		})
+	}
+
+	t.Run("device in second site", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{sitesPage, siteADevices(), siteBDevices()}
+
+		siteID, device, err := client.FindDeviceByMAC(ctx, "AA-BB-CC-DD-EE-FF")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if siteID != "site-b" {
+			t.Errorf("expected site site-b, got %s", siteID)
+		}
+		if device.ID != "b2" {
+			t.Errorf("expected device b2, got %s", device.ID)
+		}
+		if len(mock.requests) != 3 {
+			t.Errorf("expected 3 requests, got %d", len(mock.requests))
+		}
+		if got := mock.requests[2].URL.Path; !strings.HasSuffix(got, "/sites/site-b/devices") {
+			t.Errorf("expected last request for site-b devices, got %s", got)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			mockResponse(200, ListSitesResponse{
+				Count:      2,
+				TotalCount: 2,
+				Data:       []Site{{ID: "site-a"}, {ID: "site-b"}},
+			}),
+			siteADevices(),
+			siteBDevices(),
+		}
+
+		_, _, err := client.FindDeviceByMAC(ctx, "de:ad:be:ef:00:01")
+		if !IsNotFound(err) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("invalid MAC", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		if _, _, err := client.FindDeviceByMAC(ctx, "not-a-mac"); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+}
+
+// trackingBody records how much of a response body has been read and whether it was closed
+type trackingBody struct {
+	r      io.Reader
+	read   int
+	closed bool
+}
+
+func (b *trackingBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.read += n
+	return n, err
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestClient_DownloadDeviceSupportInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stream is passed through", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		content := strings.Repeat("support-info-bundle\n", 1000)
+		body := &trackingBody{r: strings.NewReader(content)}
+		mock.response = &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       body,
+			Header:     http.Header{"Content-Type": {"application/octet-stream"}},
+		}
+
+		rc, err := client.DownloadDeviceSupportInfo(ctx, testSiteID, "device1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if body.read != 0 {
+			t.Errorf("expected body to be unread before the caller reads it, %d bytes already read", body.read)
+		}
+		if got := mock.request.URL.Path; !strings.HasSuffix(got, "/sites/default/devices/device1/support-info") {
+			t.Errorf("unexpected request path: %s", got)
+		}
+
+		got, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		if string(got) != content {
+			t.Errorf("expected %d bytes of content, got %d", len(content), len(got))
+		}
+
+		if err := rc.Close(); err != nil {
+			t.Fatalf("unexpected close error: %v", err)
+		}
+		if !body.closed {
+			t.Error("expected closing the stream to close the response body")
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(404, Error{Status: 404, StatusName: "Not Found", Message: "Device not found"})
+
+		rc, err := client.DownloadDeviceSupportInfo(ctx, testSiteID, "missing")
+		if rc != nil {
+			t.Error("expected no stream on error")
+		}
+		assertErrorResponse(t, err, 404, "Device not found")
+	})
+
+	t.Run("missing device ID", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if _, err := client.DownloadDeviceSupportInfo(ctx, testSiteID, ""); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestDevice_JSONRoundTrip(t *testing.T) {
+	raw := `{"_id":"device1","mac":"00:11:22:33:44:55","model":"U6-Pro","type":"uap","name":"Office AP"}`
+
+	var device Device
+	if err := json.Unmarshal([]byte(raw), &device); err != nil {
+		t.Fatalf("failed to unmarshal device: %v", err)
+	}
+	if device.Type != DeviceTypeAccessPoint {
+		t.Fatalf("expected type %q, got %q", DeviceTypeAccessPoint, device.Type)
+	}
+
+	encoded, err := json.Marshal(device)
+	if err != nil {
+		t.Fatalf("failed to marshal device: %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatalf("failed to unmarshal encoded device: %v", err)
+	}
+	if fields["type"] != DeviceTypeAccessPoint {
+		t.Errorf("expected encoded type %q, got %v", DeviceTypeAccessPoint, fields["type"])
+	}
+
+	var decoded Device
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal encoded device: %v", err)
+	}
+	if decoded != device {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", decoded, device)
+	}
+}
+
+func TestClient_OverheatingDevices(t *testing.T) {
+	ctx := context.Background()
+
+	statsResponse := func(temp float64) *http.Response {
+		var s DeviceStatistics
+		s.SystemStats.Temperature = temp
+		return mockResponse(200, struct {
+			Data []DeviceStatistics `json:"data"`
+		}{Data: []DeviceStatistics{s}})
+	}
+
+	t.Run("returns devices above the threshold", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		client.concurrency = 1 // mockTransport serves queued responses in order
+
+		mock.responses = []*http.Response{
+			mockResponse(200, ListDevicesResponse{
+				PaginatedResponse: PaginatedResponse{Count: 4, TotalCount: 4},
+				Data: []Device{
+					{ID: "hot", MAC: "00:11:22:33:44:01"},
+					{ID: "cool", MAC: "00:11:22:33:44:02"},
+					{ID: "no-sensor", MAC: "00:11:22:33:44:03"},
+					{ID: "at-limit", MAC: "00:11:22:33:44:04"},
+				},
+			}),
+			statsResponse(82.5),
+			statsResponse(45),
+			statsResponse(0),
+			statsResponse(75),
+		}
+
+		hot, err := client.OverheatingDevices(ctx, testSiteID, 75)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(hot) != 1 {
+			t.Fatalf("expected 1 overheating device, got %d: %+v", len(hot), hot)
+		}
+		if hot[0].ID != "hot" || hot[0].MAC != "00:11:22:33:44:01" {
+			t.Errorf("expected device hot with its MAC, got %s/%s", hot[0].ID, hot[0].MAC)
+		}
+		if hot[0].SystemStats.Temperature != 82.5 {
+			t.Errorf("expected temperature 82.5, got %v", hot[0].SystemStats.Temperature)
+		}
+		if len(mock.requests) != 5 {
+			t.Errorf("expected 5 requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("stats error is returned", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		client.concurrency = 1
+
+		mock.responses = []*http.Response{
+			mockResponse(200, ListDevicesResponse{
+				PaginatedResponse: PaginatedResponse{Count: 1, TotalCount: 1},
+				Data:              []Device{{ID: "ap1"}},
+			}),
+		}
+		mock.response = mockResponse(500, map[string]string{"message": "boom"})
+
+		if _, err := client.OverheatingDevices(ctx, testSiteID, 75); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestClient_DisconnectClientFromDevice(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("request body", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, nil)
+
+		if err := client.DisconnectClientFromDevice(ctx, testSiteID, "ap1", "AA-BB-CC-DD-EE-FF"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if mock.request.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", mock.request.Method)
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/default/devices/ap1" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+		var command ClientCommand
+		decodeRequestBody(t, mock.request, &command)
+		if want := (ClientCommand{Cmd: "kick-sta", MAC: "aa:bb:cc:dd:ee:ff"}); command != want {
+			t.Errorf("expected body %+v, got %+v", want, command)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			deviceID string
+			mac      string
+		}{
+			{name: "invalid MAC", deviceID: "ap1", mac: "not-a-mac"},
+			{name: "short MAC", deviceID: "ap1", mac: "aa:bb:cc:dd:ee"},
+			{name: "empty MAC", deviceID: "ap1", mac: ""},
+			{name: "missing device", deviceID: "", mac: "aa:bb:cc:dd:ee:ff"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client, mock := newTestClient(t, testBaseURL)
+
+				if err := client.DisconnectClientFromDevice(ctx, testSiteID, tt.deviceID, tt.mac); err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if len(mock.requests) != 0 {
+					t.Errorf("expected no requests, got %d", len(mock.requests))
+				}
+			})
+		}
+	})
+}
+
+func TestClient_SetDeviceMgmtVLAN(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("PATCH body", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, nil)
+
+		if err := client.SetDeviceMgmtVLAN(ctx, testSiteID, "sw1", 20); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if mock.request.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", mock.request.Method)
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/default/devices/sw1" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+		var body map[string]interface{}
+		decodeRequestBody(t, mock.request, &body)
+		if len(body) != 1 || body["mgmt_vlan"] != float64(20) {
+			t.Errorf(`expected body {"mgmt_vlan":20}, got %v`, body)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, nil)
+
+		if err := client.SetDeviceMgmtVLAN(ctx, testSiteID, "sw1", 20); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// The controller echoes the patched field back on the device
+		var sent map[string]interface{}
+		decodeRequestBody(t, mock.request, &sent)
+		mock.response = mockResponse(200, struct {
+			Data []map[string]interface{} `json:"data"`
+		}{
+			Data: []map[string]interface{}{{"_id": "sw1", "mgmt_vlan": sent["mgmt_vlan"]}},
+		})
+
+		device, err := client.GetDevice(ctx, testSiteID, "sw1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if device.ManagementVLAN != 20 {
+			t.Errorf("expected management VLAN 20, got %d", device.ManagementVLAN)
+		}
+	})
+
+	t.Run("valid range", func(t *testing.T) {
+		for _, vlan := range []int{0, 1, 4095} {
+			client, mock := newTestClient(t, testBaseURL)
+			mock.response = mockResponse(200, nil)
+
+			if err := client.SetDeviceMgmtVLAN(ctx, testSiteID, "sw1", vlan); err != nil {
+				t.Errorf("unexpected error for vlan %d: %v", vlan, err)
+				continue
+			}
+			// VLAN 0 must still be sent rather than omitted
+			var body map[string]interface{}
+			decodeRequestBody(t, mock.request, &body)
+			if body["mgmt_vlan"] != float64(vlan) {
+				t.Errorf("expected mgmt_vlan %d, got %v", vlan, body["mgmt_vlan"])
+			}
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		for _, vlan := range []int{-1, 4096} {
+			client, mock := newTestClient(t, testBaseURL)
+
+			if err := client.SetDeviceMgmtVLAN(ctx, testSiteID, "sw1", vlan); err == nil {
+				t.Errorf("expected error for vlan %d, got nil", vlan)
+			}
+			if len(mock.requests) != 0 {
+				t.Errorf("expected no requests for vlan %d, got %d", vlan, len(mock.requests))
+			}
+		}
+	})
+
+	t.Run("missing device ID", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		if err := client.SetDeviceMgmtVLAN(ctx, testSiteID, "", 20); err == nil {
+			t.Error("expected error, got nil")
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+}
+
+func TestClient_AdoptDevice(t *testing.T) {
+	ctx := context.Background()
+
+	deviceResponse := func(device Device) *http.Response {
+		return mockResponse(200, map[string]interface{}{"data": []Device{device}})
+	}
+
+	t.Run("adopts pending device", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			deviceResponse(Device{ID: "ap1", Adopted: false}),
+			mockResponse(200, nil),
+		}
+
+		adopted, err := client.AdoptDevice(ctx, testSiteID, "ap1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !adopted {
+			t.Error("expected adopted to be true")
+		}
+
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected get and adopt requests, got %d", len(mock.requests))
+		}
+		adopt := mock.requests[1]
+		if adopt.Method != http.MethodPost || adopt.URL.Path != "/proxy/network/integration/v1/sites/default/devices/ap1" {
+			t.Errorf("unexpected adopt request: %s %s", adopt.Method, adopt.URL.Path)
+		}
+		var action DeviceAction
+		decodeRequestBody(t, adopt, &action)
+		if action.Action != "adopt" {
+			t.Errorf("expected adopt action, got %q", action.Action)
+		}
+	})
+
+	t.Run("no-op on adopted device", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{deviceResponse(Device{ID: "sw1", Adopted: true})}
+
+		adopted, err := client.AdoptDevice(ctx, testSiteID, "sw1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if adopted {
+			t.Error("expected adopted to be false for an adopted device")
+		}
+		if len(mock.requests) != 1 || mock.requests[0].Method != http.MethodGet {
+			t.Errorf("expected only the lookup request, got %d requests", len(mock.requests))
+		}
+	})
+
+	t.Run("missing device", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{"data": []Device{}})
+
+		if _, err := client.AdoptDevice(ctx, testSiteID, "gone"); !IsNotFound(err) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestClient_AdoptAllPending(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("adopts pending devices and joins failures", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		client.concurrency = 1 // mockTransport serves queued responses in order
+
+		mock.responses = []*http.Response{
+			mockResponse(200, ListDevicesResponse{
+				PaginatedResponse: PaginatedResponse{Count: 5, TotalCount: 5},
+				Data: []Device{
+					{ID: "ap1", Adopted: false},
+					{ID: "sw1", Adopted: true},
+					{ID: "ap2", Adopted: false},
+					{ID: "ap3", Adopted: false},
+					{ID: "ap4", Adopted: false},
+				},
+			}),
+			mockResponse(200, nil),
+			mockResponse(400, Error{Status: 400, Message: "device is offline"}),
+			mockResponse(200, nil),
+			mockResponse(200, nil),
+		}
+
+		adopted, err := client.AdoptAllPending(ctx, testSiteID)
+		if adopted != 3 {
+			t.Errorf("expected 3 adopted devices, got %d", adopted)
+		}
+		if err == nil {
+			t.Fatal("expected error for the failed adoption, got nil")
+		}
+		if !strings.Contains(err.Error(), "ap2") || strings.Contains(err.Error(), "ap3") {
+			t.Errorf("expected error naming only ap2, got %v", err)
+		}
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Status != 400 {
+			t.Errorf("expected wrapped API error, got %v", err)
+		}
+
+		// One list request plus one adopt per pending device
+		if len(mock.requests) != 5 {
+			t.Fatalf("expected 5 requests, got %d", len(mock.requests))
+		}
+		for _, req := range mock.requests[1:] {
+			if req.Method != http.MethodPost {
+				t.Errorf("expected POST, got %s", req.Method)
+			}
+			if strings.HasSuffix(req.URL.Path, "/sw1") {
+				t.Error("adopted device sw1 should not be adopted again")
+			}
+		}
+	})
+
+	t.Run("nothing pending", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Count: 1, TotalCount: 1},
+			Data:              []Device{{ID: "sw1", Adopted: true}},
+		})
+
+		adopted, err := client.AdoptAllPending(ctx, testSiteID)
+		if err != nil || adopted != 0 {
+			t.Errorf("expected 0 adopted and no error, got %d, %v", adopted, err)
+		}
+	})
+}
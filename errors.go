@@ -0,0 +1,58 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrControllerUnavailable is returned (wrapped in *Error) when the controller
+// responds with a non-JSON 503, typically its maintenance page during upgrades
+var ErrControllerUnavailable = errors.New("controller unavailable")
+
+// IsUnavailable reports whether err indicates the controller is temporarily unavailable
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrControllerUnavailable)
+}
+
+// ErrNotFound is matched by errors.Is for every "not found" result: a
+// NotFoundError for an empty lookup or an API *Error with a 404 status
+var ErrNotFound = errors.New("not found")
+
+// NotFoundError is returned when a lookup by ID finds nothing
+type NotFoundError struct {
+	Resource string // Kind of resource looked up (e.g., "device", "voucher")
+	ID       string // ID that was looked up
+}
+
+// Error implements the error interface
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.Resource, e.ID)
+}
+
+// Is reports whether target is ErrNotFound
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// IsNotFound reports whether err indicates the requested resource does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsTimeout reports whether err is the result of a deadline: the request's
+// context deadline passing, an http.Client Timeout, or a network timeout.
+// It is false for a cancelled context; see IsCanceled.
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// IsCanceled reports whether err is the result of the request's context
+// being cancelled, for example because the caller gave up or is shutting down
+func IsCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
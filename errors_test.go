@@ -0,0 +1,197 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestErrNotFound(t *testing.T) {
+	ctx := context.Background()
+	emptyData := `{"data":[]}`
+
+	tests := []struct {
+		name        string
+		wantMessage string
+		call        func(c *Client) error
+	}{
+		{
+			name:        "device",
+			wantMessage: "device not found: missing",
+			call: func(c *Client) error {
+				_, err := c.GetDevice(ctx, testSiteID, "missing")
+				return err
+			},
+		},
+		{
+			name:        "network client",
+			wantMessage: "network client not found: missing",
+			call: func(c *Client) error {
+				_, err := c.GetNetworkClient(ctx, testSiteID, "missing")
+				return err
+			},
+		},
+		{
+			name:        "network client update",
+			wantMessage: "network client not found: missing",
+			call: func(c *Client) error {
+				_, err := c.SetClientName(ctx, testSiteID, "missing", "Laptop")
+				return err
+			},
+		},
+		{
+			name:        "voucher",
+			wantMessage: "voucher not found: missing",
+			call: func(c *Client) error {
+				_, err := c.GetHotspotVoucher(ctx, testSiteID, "missing")
+				return err
+			},
+		},
+		{
+			name:        "voucher details",
+			wantMessage: "voucher not found: missing",
+			call: func(c *Client) error {
+				_, err := c.GetVoucherDetails(ctx, testSiteID, "missing")
+				return err
+			},
+		},
+		{
+			name:        "site",
+			wantMessage: "site not found: missing",
+			call: func(c *Client) error {
+				_, err := c.GetSite(ctx, "missing")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := newTestClient(t, testBaseURL)
+			mock.response = mockRawResponse(200, emptyData)
+
+			err := tt.call(client)
+			if !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+			if !IsNotFound(err) {
+				t.Errorf("expected IsNotFound to be true for %v", err)
+			}
+
+			var notFound *NotFoundError
+			if !errors.As(err, &notFound) {
+				t.Fatalf("expected *NotFoundError, got %T", err)
+			}
+			if notFound.ID != "missing" {
+				t.Errorf("expected ID %q, got %q", "missing", notFound.ID)
+			}
+			if notFound.Error() != tt.wantMessage {
+				t.Errorf("expected message %q, got %q", tt.wantMessage, notFound.Error())
+			}
+		})
+	}
+
+	t.Run("API 404", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(404, Error{Status: 404, StatusName: "Not Found", Message: "Device not found"})
+
+		_, err := client.GetDevice(ctx, testSiteID, "missing")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("other errors do not match", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(500, Error{Status: 500, StatusName: "Internal Server Error", Message: "boom"})
+
+		_, err := client.GetDevice(ctx, testSiteID, "device1")
+		if IsNotFound(err) {
+			t.Errorf("expected 500 not to match ErrNotFound: %v", err)
+		}
+	})
+}
+
+// netTimeoutError mimics the net.Error returned for dial and read timeouts
+type netTimeoutError struct{}
+
+func (netTimeoutError) Error() string   { return "i/o timeout" }
+func (netTimeoutError) Timeout() bool   { return true }
+func (netTimeoutError) Temporary() bool { return true }
+
+func TestIsTimeoutAndIsCanceled(t *testing.T) {
+	t.Run("cancelled context", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.GetApplicationInfo(ctx)
+		if !IsCanceled(err) {
+			t.Errorf("expected IsCanceled for %v", err)
+		}
+		if IsTimeout(err) {
+			t.Errorf("expected cancel not to be a timeout: %v", err)
+		}
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		_, err := client.GetApplicationInfo(ctx)
+		if !IsTimeout(err) {
+			t.Errorf("expected IsTimeout for %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected errors.Is DeadlineExceeded for %v", err)
+		}
+		if IsCanceled(err) {
+			t.Errorf("expected deadline not to be a cancel: %v", err)
+		}
+	})
+
+	t.Run("network timeout", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.err = netTimeoutError{}
+
+		_, err := client.GetApplicationInfo(context.Background())
+		if !IsTimeout(err) {
+			t.Errorf("expected IsTimeout for %v", err)
+		}
+		if IsCanceled(err) {
+			t.Errorf("expected timeout not to be a cancel: %v", err)
+		}
+	})
+
+	t.Run("cancelled while waiting to retry", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		clock := newFakeClock()
+		client.clock = clock
+		client.maxRetries = 3
+		mock.response = mockRawResponse(503, "<html>maintenance</html>")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errc := make(chan error, 1)
+		go func() {
+			_, err := client.GetApplicationInfo(ctx)
+			errc <- err
+		}()
+		clock.waitForWaiters(t, 1)
+		cancel()
+
+		err := <-errc
+		if !IsCanceled(err) {
+			t.Errorf("expected IsCanceled for %v", err)
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		for _, err := range []error{nil, errors.New("boom"), &Error{Status: 504, Message: "Gateway Timeout"}} {
+			if IsTimeout(err) || IsCanceled(err) {
+				t.Errorf("expected %v to be neither timeout nor cancel", err)
+			}
+		}
+	})
+}
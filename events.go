@@ -0,0 +1,72 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Event represents a single entry in a site's event/alarm log
+type Event struct {
+	ID         string `json:"_id"`         // Unique identifier
+	Time       int64  `json:"time"`        // Event timestamp in milliseconds since epoch
+	DeviceID   string `json:"device_id"`   // ID of the device the event relates to
+	DeviceMAC  string `json:"device_mac"`  // MAC of the device the event relates to
+	DeviceName string `json:"device_name"` // Name of the device the event relates to
+	Type       string `json:"key"`         // Event type (e.g., EVT_AP_Lost_Contact)
+	Message    string `json:"msg"`         // Human readable event message
+	Severity   string `json:"severity"`    // Event severity (info, warning, critical)
+}
+
+// Timestamp returns the event time as a time.Time
+func (e Event) Timestamp() time.Time {
+	return time.UnixMilli(e.Time)
+}
+
+// EventParams contains parameters for listing events
+type EventParams struct {
+	Offset   int       `json:"offset,omitempty"`   // Default: 0
+	Limit    int       `json:"limit,omitempty"`    // [0..200] or LimitMax, Default: 25
+	Start    time.Time `json:"start,omitempty"`    // Only return events at or after this time
+	End      time.Time `json:"end,omitempty"`      // Only return events before this time
+	Severity string    `json:"severity,omitempty"` // Only return events with this severity
+}
+
+// ListDeviceEventsResponse represents the response from listing events
+type ListDeviceEventsResponse struct {
+	PaginatedResponse
+	Data []Event `json:"data"`
+}
+
+// ListDeviceEvents retrieves a paginated list of device events for a site
+func (c *Client) ListDeviceEvents(ctx context.Context, siteID string, params *EventParams) (*ListDeviceEventsResponse, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/events", url.PathEscape(siteID))
+
+	if params != nil {
+		if !params.Start.IsZero() && !params.End.IsZero() && params.End.Before(params.Start) {
+			return nil, fmt.Errorf("end must not be before start")
+		}
+
+		query, err := buildQuery(params, c.maxPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		if len(query) > 0 {
+			urlPath += "?" + query.Encode()
+		}
+	}
+
+	var response ListDeviceEventsResponse
+	err := c.do(ctx, http.MethodGet, urlPath, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list device events: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,142 @@
+package unifi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_ListDeviceEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, ListDeviceEventsResponse{
+			PaginatedResponse: PaginatedResponse{
+				Offset:     0,
+				Limit:      25,
+				Count:      1,
+				TotalCount: 1,
+			},
+			Data: []Event{
+				{
+					ID:         "evt1",
+					Time:       1700000000000,
+					DeviceMAC:  "00:11:22:33:44:55",
+					DeviceName: "Office AP",
+					Type:       "EVT_AP_Lost_Contact",
+					Message:    "AP lost contact",
+					Severity:   "warning",
+				},
+			},
+		})
+
+		result, err := client.ListDeviceEvents(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		assertPaginatedResponse(t, result.PaginatedResponse, PaginatedResponse{
+			Offset:     0,
+			Limit:      25,
+			Count:      1,
+			TotalCount: 1,
+		})
+
+		if len(result.Data) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(result.Data))
+		}
+		event := result.Data[0]
+		if event.Type != "EVT_AP_Lost_Contact" {
+			t.Errorf("expected event type %s, got %s", "EVT_AP_Lost_Contact", event.Type)
+		}
+		if !event.Timestamp().Equal(time.UnixMilli(1700000000000)) {
+			t.Errorf("expected timestamp %v, got %v", time.UnixMilli(1700000000000), event.Timestamp())
+		}
+	})
+
+	t.Run("query encoding", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListDeviceEventsResponse{})
+
+		start := time.UnixMilli(1700000000000)
+		end := start.Add(time.Hour)
+		_, err := client.ListDeviceEvents(ctx, testSiteID, &EventParams{
+			Offset:   50,
+			Limit:    10,
+			Start:    start,
+			End:      end,
+			Severity: "critical",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		query := mock.request.URL.Query()
+		want := map[string]string{
+			"offset":   "50",
+			"limit":    "10",
+			"start":    "1700000000000",
+			"end":      "1700003600000",
+			"severity": "critical",
+		}
+		for key, value := range want {
+			if got := query.Get(key); got != value {
+				t.Errorf("expected query %s=%s, got %s", key, value, got)
+			}
+		}
+	})
+
+	t.Run("with pagination parameters", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, ListDeviceEventsResponse{
+			PaginatedResponse: PaginatedResponse{
+				Offset:     100,
+				Limit:      50,
+				Count:      0,
+				TotalCount: 120,
+			},
+			Data: []Event{},
+		})
+
+		result, err := client.ListDeviceEvents(ctx, testSiteID, &EventParams{Offset: 100, Limit: 50})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		assertPaginatedResponse(t, result.PaginatedResponse, PaginatedResponse{
+			Offset:     100,
+			Limit:      50,
+			Count:      0,
+			TotalCount: 120,
+		})
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		start := time.Now()
+		_, err := client.ListDeviceEvents(ctx, testSiteID, &EventParams{
+			Start: start,
+			End:   start.Add(-time.Hour),
+		})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(404, Error{
+			Status:     404,
+			StatusName: "Not Found",
+			Message:    "Site not found",
+		})
+
+		_, err := client.ListDeviceEvents(ctx, "nonexistent", nil)
+		assertErrorResponse(t, err, 404, "Site not found")
+	})
+}
@@ -0,0 +1,147 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport records the maximum number of simultaneous in-flight requests
+type countingTransport struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+	delay       time.Duration
+	failID      string
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	current := t.inFlight.Add(1)
+	defer t.inFlight.Add(-1)
+	for {
+		peak := t.maxInFlight.Load()
+		if current <= peak || t.maxInFlight.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(t.delay):
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	id := path.Base(req.URL.Path)
+	if id == t.failID {
+		return mockResponse(404, Error{Status: 404, StatusName: "Not Found", Message: "Device not found"}), nil
+	}
+	return mockResponse(200, struct {
+		Data []Device `json:"data"`
+	}{
+		Data: []Device{{ID: id}},
+	}), nil
+}
+
+func newCountingClient(t *testing.T, transport *countingTransport, options ...ClientOption) *Client {
+	t.Helper()
+	options = append([]ClientOption{
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithAPIKey("test-api-key"),
+	}, options...)
+	client, err := NewClient(testBaseURL, options...)
+	if err != nil {
+		t.Fatalf("failed to create test client: %v", err)
+	}
+	return client
+}
+
+func deviceIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("device-%d", i)
+	}
+	return ids
+}
+
+func TestWithConcurrency(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		for _, n := range []int{0, -1} {
+			_, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithConcurrency(n))
+			if err == nil {
+				t.Errorf("expected error for concurrency %d, got nil", n)
+			}
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+		if client.concurrency != defaultConcurrency {
+			t.Errorf("expected default concurrency %d, got %d", defaultConcurrency, client.concurrency)
+		}
+	})
+}
+
+func TestClient_GetDevices(t *testing.T) {
+	ctx := context.Background()
+
+	for _, concurrency := range []int{1, 3} {
+		t.Run(fmt.Sprintf("concurrency %d bounds in-flight requests", concurrency), func(t *testing.T) {
+			transport := &countingTransport{delay: 10 * time.Millisecond}
+			client := newCountingClient(t, transport, WithConcurrency(concurrency))
+
+			ids := deviceIDs(12)
+			devices, err := client.GetDevices(ctx, testSiteID, ids)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := transport.maxInFlight.Load(); got > int32(concurrency) {
+				t.Errorf("expected at most %d in-flight requests, got %d", concurrency, got)
+			}
+			for i, device := range devices {
+				if device.ID != ids[i] {
+					t.Errorf("expected device %d to have ID %s, got %s", i, ids[i], device.ID)
+				}
+			}
+		})
+	}
+
+	t.Run("first error is returned", func(t *testing.T) {
+		transport := &countingTransport{delay: time.Millisecond, failID: "device-4"}
+		client := newCountingClient(t, transport, WithConcurrency(2))
+
+		_, err := client.GetDevices(ctx, testSiteID, deviceIDs(10))
+		assertErrorResponse(t, err, 404, "Device not found")
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		client := newCountingClient(t, &countingTransport{})
+
+		devices, err := client.GetDevices(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(devices) != 0 {
+			t.Errorf("expected no devices, got %d", len(devices))
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		client := newCountingClient(t, &countingTransport{delay: time.Second})
+
+		ctx, cancel := context.WithCancel(ctx)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		_, err := client.GetDevices(ctx, testSiteID, deviceIDs(10))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// FleetUpdateSummary summarizes firmware update availability across a site's devices
+type FleetUpdateSummary struct {
+	TotalDevices     int                  `json:"totalDevices"`     // Number of devices on the site
+	UpdatesAvailable int                  `json:"updatesAvailable"` // Number of devices with an update available
+	Models           []ModelUpdateSummary `json:"models"`           // Per-model breakdown, sorted by model
+}
+
+// ModelUpdateSummary summarizes firmware update availability for one device model
+type ModelUpdateSummary struct {
+	Model             string   `json:"model"`             // Device model
+	Total             int      `json:"total"`             // Number of devices of this model
+	UpdatesAvailable  int      `json:"updatesAvailable"`  // Number of devices of this model with an update available
+	CurrentVersions   []string `json:"currentVersions"`   // Distinct firmware versions currently installed
+	AvailableVersions []string `json:"availableVersions"` // Distinct firmware versions offered as upgrades, if reported
+}
+
+// CheckFirmwareUpdates lists every device on a site and summarizes which
+// models have firmware updates available
+func (c *Client) CheckFirmwareUpdates(ctx context.Context, siteID string) (*FleetUpdateSummary, error) {
+	devices, err := c.ListAllDevices(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check firmware updates: %w", err)
+	}
+
+	return summarizeFirmwareUpdates(devices), nil
+}
+
+// summarizeFirmwareUpdates groups devices by model and counts pending updates
+func summarizeFirmwareUpdates(devices []Device) *FleetUpdateSummary {
+	type modelVersions struct {
+		summary   ModelUpdateSummary
+		current   map[string]bool
+		available map[string]bool
+	}
+
+	byModel := make(map[string]*modelVersions)
+	summary := &FleetUpdateSummary{TotalDevices: len(devices)}
+	for _, device := range devices {
+		m, ok := byModel[device.Model]
+		if !ok {
+			m = &modelVersions{
+				summary:   ModelUpdateSummary{Model: device.Model},
+				current:   make(map[string]bool),
+				available: make(map[string]bool),
+			}
+			byModel[device.Model] = m
+		}
+
+		m.summary.Total++
+		if device.Version != "" {
+			m.current[device.Version] = true
+		}
+		if device.NeedsUpgrade() {
+			m.summary.UpdatesAvailable++
+			summary.UpdatesAvailable++
+			if device.UpgradeToFirmware != "" {
+				m.available[device.UpgradeToFirmware] = true
+			}
+		}
+	}
+
+	summary.Models = make([]ModelUpdateSummary, 0, len(byModel))
+	for _, m := range byModel {
+		m.summary.CurrentVersions = sortedKeys(m.current)
+		m.summary.AvailableVersions = sortedKeys(m.available)
+		summary.Models = append(summary.Models, m.summary)
+	}
+	sort.Slice(summary.Models, func(i, j int) bool {
+		return summary.Models[i].Model < summary.Models[j].Model
+	})
+
+	return summary
+}
+
+// sortedKeys returns the keys of set in ascending order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
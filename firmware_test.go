@@ -0,0 +1,77 @@
+package unifi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeFirmwareUpdates(t *testing.T) {
+	devices := []Device{
+		{ID: "1", Model: "U6-Pro", Version: "6.5.28", Upgradable: true, UpgradeToFirmware: "6.6.55"},
+		{ID: "2", Model: "U6-Pro", Version: "6.6.55"},
+		{ID: "3", Model: "U6-Pro", Version: "6.5.28", Upgradable: true, UpgradeToFirmware: "6.6.55"},
+		{ID: "4", Model: "USW-24", Version: "7.0.50", Upgradable: true},
+		{ID: "5", Model: "UDM-Pro", Version: "4.0.6"},
+	}
+
+	got := summarizeFirmwareUpdates(devices)
+
+	want := &FleetUpdateSummary{
+		TotalDevices:     5,
+		UpdatesAvailable: 3,
+		Models: []ModelUpdateSummary{
+			{
+				Model:             "U6-Pro",
+				Total:             3,
+				UpdatesAvailable:  2,
+				CurrentVersions:   []string{"6.5.28", "6.6.55"},
+				AvailableVersions: []string{"6.6.55"},
+			},
+			{
+				Model:             "UDM-Pro",
+				Total:             1,
+				CurrentVersions:   []string{"4.0.6"},
+				AvailableVersions: []string{},
+			},
+			{
+				Model:             "USW-24",
+				Total:             1,
+				UpdatesAvailable:  1,
+				CurrentVersions:   []string{"7.0.50"},
+				AvailableVersions: []string{},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("summarizeFirmwareUpdates() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	t.Run("no devices", func(t *testing.T) {
+		got := summarizeFirmwareUpdates(nil)
+		if got.TotalDevices != 0 || got.UpdatesAvailable != 0 || got.Models == nil || len(got.Models) != 0 {
+			t.Errorf("unexpected summary for no devices: %+v", got)
+		}
+	})
+}
+
+func TestClient_CheckFirmwareUpdates(t *testing.T) {
+	client, mock := newTestClient(t, testBaseURL)
+	mock.response = mockRawResponse(200, `{"offset":0,"limit":200,"count":2,"totalCount":2,"data":[
+		{"_id":"1","model":"U6-Lite","version":"6.5.28","upgradable":true,"upgrade_to_firmware":"6.6.55"},
+		{"_id":"2","model":"U6-Lite","version":"6.6.55","upgradable":false}
+	]}`)
+
+	summary, err := client.CheckFirmwareUpdates(context.Background(), testSiteID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.TotalDevices != 2 || summary.UpdatesAvailable != 1 {
+		t.Errorf("expected 1 of 2 devices to need updates, got %d of %d", summary.UpdatesAvailable, summary.TotalDevices)
+	}
+	if len(summary.Models) != 1 || !reflect.DeepEqual(summary.Models[0].AvailableVersions, []string{"6.6.55"}) {
+		t.Errorf("unexpected model summary: %+v", summary.Models)
+	}
+}
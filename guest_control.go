@@ -0,0 +1,108 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Guest portal authentication types
+const (
+	GuestAuthNone     = "none"     // Open portal, guests only accept the terms
+	GuestAuthHotspot  = "hotspot"  // Vouchers or other hotspot methods
+	GuestAuthPassword = "password" // A shared portal password
+	GuestAuthExternal = "external" // An external portal server
+)
+
+var knownGuestAuthTypes = map[string]bool{
+	GuestAuthNone:     true,
+	GuestAuthHotspot:  true,
+	GuestAuthPassword: true,
+	GuestAuthExternal: true,
+}
+
+// GuestControl represents a site's guest portal and guest network policy
+type GuestControl struct {
+	PortalEnabled   bool   `json:"portal_enabled"`         // Whether guests are sent to the portal
+	AuthType        string `json:"auth"`                   // Portal authentication (GuestAuthNone, GuestAuthHotspot, ...)
+	ExpireMinutes   int    `json:"expire"`                 // How long an authorization lasts, in minutes
+	RedirectEnabled bool   `json:"redirect_enabled"`       // Whether guests are redirected after authorizing
+	RedirectURL     string `json:"redirect_url,omitempty"` // Where guests are redirected, if RedirectEnabled
+	Isolation       bool   `json:"guest_isolation"`        // Whether guests are isolated from each other
+}
+
+// validate checks the settings before they are sent to the controller
+func (g *GuestControl) validate() error {
+	if g.AuthType != "" && !knownGuestAuthTypes[g.AuthType] {
+		return fmt.Errorf("invalid auth type %q", g.AuthType)
+	}
+	if g.ExpireMinutes < 0 {
+		return fmt.Errorf("expire must not be negative")
+	}
+	if g.RedirectEnabled && g.RedirectURL == "" {
+		return fmt.Errorf("redirect URL is required when redirect is enabled")
+	}
+	if g.RedirectURL != "" {
+		u, err := url.Parse(g.RedirectURL)
+		if err != nil {
+			return fmt.Errorf("invalid redirect URL %q: %w", g.RedirectURL, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid redirect URL %q: scheme must be http or https", g.RedirectURL)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("invalid redirect URL %q: missing host", g.RedirectURL)
+		}
+	}
+	return nil
+}
+
+// GetGuestControl retrieves a site's guest portal and guest network policy
+func (c *Client) GetGuestControl(ctx context.Context, siteID string) (*GuestControl, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data []GuestControl `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/guest-control", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodGet, urlPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get guest control: %w", err)
+	}
+
+	if len(response.Data) == 0 {
+		return nil, &NotFoundError{Resource: "guest control", ID: siteID}
+	}
+
+	return &response.Data[0], nil
+}
+
+// UpdateGuestControl replaces a site's guest portal and guest network policy
+// and returns the settings the controller stored. Fetch the current settings
+// with GetGuestControl first to change individual fields.
+func (c *Client) UpdateGuestControl(ctx context.Context, siteID string, settings GuestControl) (*GuestControl, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data []GuestControl `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/guest-control", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodPut, urlPath, &settings, &response); err != nil {
+		return nil, fmt.Errorf("failed to update guest control: %w", err)
+	}
+
+	if len(response.Data) == 0 {
+		return &settings, nil
+	}
+
+	return &response.Data[0], nil
+}
@@ -0,0 +1,136 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_GetGuestControl(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{
+			"data": []map[string]interface{}{{
+				"portal_enabled":   true,
+				"auth":             "hotspot",
+				"expire":           480,
+				"redirect_enabled": true,
+				"redirect_url":     "https://example.com/welcome",
+				"guest_isolation":  true,
+			}},
+		})
+
+		settings, err := client.GetGuestControl(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := GuestControl{
+			PortalEnabled:   true,
+			AuthType:        GuestAuthHotspot,
+			ExpireMinutes:   480,
+			RedirectEnabled: true,
+			RedirectURL:     "https://example.com/welcome",
+			Isolation:       true,
+		}
+		if *settings != want {
+			t.Errorf("expected %+v, got %+v", want, *settings)
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/default/guest-control" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{"data": []interface{}{}})
+
+		if _, err := client.GetGuestControl(ctx, testSiteID); !IsNotFound(err) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestClient_UpdateGuestControl(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("request body", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		settings := GuestControl{
+			PortalEnabled: true,
+			AuthType:      GuestAuthPassword,
+			ExpireMinutes: 60,
+			Isolation:     true,
+		}
+		mock.response = mockResponse(200, map[string]interface{}{"data": []GuestControl{settings}})
+
+		updated, err := client.UpdateGuestControl(ctx, testSiteID, settings)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *updated != settings {
+			t.Errorf("expected %+v, got %+v", settings, *updated)
+		}
+
+		if mock.request.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", mock.request.Method)
+		}
+		var sent map[string]interface{}
+		decodeRequestBody(t, mock.request, &sent)
+		want := map[string]interface{}{
+			"portal_enabled":   true,
+			"auth":             "password",
+			"expire":           float64(60),
+			"redirect_enabled": false,
+			"guest_isolation":  true,
+		}
+		for key, value := range want {
+			if sent[key] != value {
+				t.Errorf("expected %s=%v, got %v", key, value, sent[key])
+			}
+		}
+		if _, ok := sent["redirect_url"]; ok {
+			t.Errorf("expected redirect_url to be omitted, got %v", sent["redirect_url"])
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			settings GuestControl
+			wantErr  bool
+		}{
+			{name: "valid redirect", settings: GuestControl{RedirectEnabled: true, RedirectURL: "https://example.com/"}},
+			{name: "redirect enabled without URL", settings: GuestControl{RedirectEnabled: true}, wantErr: true},
+			{name: "relative redirect", settings: GuestControl{RedirectURL: "/welcome"}, wantErr: true},
+			{name: "unsupported scheme", settings: GuestControl{RedirectURL: "javascript:alert(1)"}, wantErr: true},
+			{name: "missing host", settings: GuestControl{RedirectURL: "https://"}, wantErr: true},
+			{name: "malformed URL", settings: GuestControl{RedirectURL: "https://exa mple.com/%zz"}, wantErr: true},
+			{name: "unknown auth type", settings: GuestControl{AuthType: "sms"}, wantErr: true},
+			{name: "negative expiry", settings: GuestControl{ExpireMinutes: -1}, wantErr: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client, mock := newTestClient(t, testBaseURL)
+				mock.response = mockResponse(200, map[string]interface{}{"data": []GuestControl{tt.settings}})
+
+				_, err := client.UpdateGuestControl(ctx, testSiteID, tt.settings)
+				if tt.wantErr {
+					if err == nil {
+						t.Fatal("expected error, got nil")
+					}
+					if len(mock.requests) != 0 {
+						t.Errorf("expected no requests, got %d", len(mock.requests))
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+		}
+	})
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // HotspotVoucher represents a UniFi hotspot voucher
@@ -26,8 +29,10 @@ type HotspotVoucher struct {
 
 // ListHotspotVouchersParams contains parameters for listing hotspot vouchers
 type ListHotspotVouchersParams struct {
-	Offset int `json:"offset,omitempty"`
-	Limit  int `json:"limit,omitempty"`
+	Offset int    `json:"offset,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"` // Next page token from a cursor-paginated response
+	Name   string `json:"name,omitempty"`   // Only vouchers whose note contains Name, ignoring case
 }
 
 // ListHotspotVouchersResponse represents the response from listing hotspot vouchers
@@ -53,15 +58,17 @@ type CreateHotspotVoucherResponse struct {
 	Data []HotspotVoucher `json:"data"`
 }
 
-// GenerateHotspotVouchersRequest represents the request to generate hotspot vouchers
+// GenerateHotspotVouchersRequest represents the request to generate hotspot vouchers.
+// Fields are validated in declaration order, so when several are invalid the
+// error reported is for the first of them.
 type GenerateHotspotVouchersRequest struct {
-	Count               int    `json:"count"`                          // [1..10000] Number of vouchers to generate, default: 1
-	Name                string `json:"name"`                           // Required: Voucher note, duplicated across all generated vouchers
-	AuthorizeGuestLimit int    `json:"authorizedGuestLimit,omitempty"` // [1..] Optional limit for guests per voucher
-	TimeLimitMinutes    int    `json:"timeLimitMinutes"`               // [1..1000000] Required: How long the voucher provides access
-	DataUsageLimitMB    int    `json:"dataUsageLimitMBytes,omitempty"` // [1..1046576] Optional data usage limit in MB
-	RxRateLimitKbps     int    `json:"rxRateLimitKbps,omitempty"`      // [2..100000] Optional download rate limit in Kbps
-	TxRateLimitKbps     int    `json:"txRateLimitKbps,omitempty"`      // [2..100000] Optional upload rate limit in Kbps
+	Count               int    `json:"count" validate:"min=1,max=10000"`                                      // [1..10000] Number of vouchers to generate, default: 1
+	Name                string `json:"name" validate:"required"`                                              // Required: Voucher note, duplicated across all generated vouchers
+	AuthorizeGuestLimit int    `json:"authorizedGuestLimit,omitempty" validate:"omitempty,min=1"`             // [1..] Optional limit for guests per voucher
+	TimeLimitMinutes    int    `json:"timeLimitMinutes" validate:"min=1,max=1000000"`                         // [1..1000000] Required: How long the voucher provides access
+	DataUsageLimitMB    int    `json:"dataUsageLimitMBytes,omitempty" validate:"omitempty,min=1,max=1046576"` // [1..1046576] Optional data usage limit in MB
+	RxRateLimitKbps     int    `json:"rxRateLimitKbps,omitempty" validate:"omitempty,min=2,max=100000"`       // [2..100000] Optional download rate limit in Kbps
+	TxRateLimitKbps     int    `json:"txRateLimitKbps,omitempty" validate:"omitempty,min=2,max=100000"`       // [2..100000] Optional upload rate limit in Kbps
 }
 
 // GenerateHotspotVouchersResponse represents the response from generating vouchers
@@ -80,20 +87,51 @@ type GetVoucherDetailsResponse struct {
 
 // ListHotspotVouchers retrieves a paginated list of hotspot vouchers for a site
 func (c *Client) ListHotspotVouchers(ctx context.Context, siteID string, params *ListHotspotVouchersParams) (*ListHotspotVouchersResponse, error) {
-	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers", siteID)
+	query, err := buildQuery(params, c.maxPageLimit)
+	if err != nil {
+		return nil, err
+	}
 
-	if params != nil {
-		query := url.Values{}
-		if params.Offset > 0 {
-			query.Set("offset", fmt.Sprint(params.Offset))
-		}
-		if params.Limit > 0 {
-			query.Set("limit", fmt.Sprint(params.Limit))
-		}
-		if len(query) > 0 {
-			urlPath += "?" + query.Encode()
+	resp, err := c.listHotspotVouchers(ctx, siteID, query)
+	if err != nil || params == nil || params.Name == "" {
+		return resp, err
+	}
+
+	// Controllers that ignore the name filter return the full page, so the
+	// filter is applied here too. Count then reflects the vouchers kept while
+	// Offset and TotalCount still describe the unfiltered list.
+	resp.Data = filterVouchersByName(resp.Data, params.Name)
+	resp.Count = len(resp.Data)
+	return resp, nil
+}
+
+// filterVouchersByName returns the vouchers whose note contains name, ignoring case
+func filterVouchersByName(vouchers []HotspotVoucher, name string) []HotspotVoucher {
+	filtered := make([]HotspotVoucher, 0, len(vouchers))
+	for _, v := range vouchers {
+		if v.matchesName(name) {
+			filtered = append(filtered, v)
 		}
 	}
+	return filtered
+}
+
+// matchesName reports whether the voucher's note contains name, ignoring case
+func (v HotspotVoucher) matchesName(name string) bool {
+	return strings.Contains(strings.ToLower(v.Name), strings.ToLower(name))
+}
+
+// listHotspotVouchers fetches one page of hotspot vouchers using pre-built query parameters
+func (c *Client) listHotspotVouchers(ctx context.Context, siteID string, query url.Values) (*ListHotspotVouchersResponse, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers", url.PathEscape(siteID))
+
+	if len(query) > 0 {
+		urlPath += "?" + query.Encode()
+	}
 
 	var response ListHotspotVouchersResponse
 	err := c.do(ctx, http.MethodGet, urlPath, nil, &response)
@@ -104,9 +142,81 @@ func (c *Client) ListHotspotVouchers(ctx context.Context, siteID string, params
 	return &response, nil
 }
 
+// ListAllHotspotVouchers retrieves every hotspot voucher for a site, following offset or cursor pagination
+func (c *Client) ListAllHotspotVouchers(ctx context.Context, siteID string) ([]HotspotVoucher, error) {
+	return collect(c.IterHotspotVouchers(ctx, siteID, nil))
+}
+
+// IsExpired reports whether the voucher has expired as of now, either because
+// the controller flagged it or because its expiry time has passed
+func (v HotspotVoucher) IsExpired(now time.Time) bool {
+	if v.Expired {
+		return true
+	}
+	if v.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, v.ExpiresAt)
+	return err == nil && !now.Before(expiresAt)
+}
+
+// RemainingTime returns how much access time an activated voucher has left as
+// of now, counting TimeLimitMinutes from ActivatedAt. It is zero for a voucher
+// that has expired or has never been activated.
+func (v HotspotVoucher) RemainingTime(now time.Time) time.Duration {
+	if v.Expired || v.ActivatedAt == "" {
+		return 0
+	}
+	activatedAt, err := time.Parse(time.RFC3339, v.ActivatedAt)
+	if err != nil {
+		return 0
+	}
+
+	remaining := activatedAt.Add(time.Duration(v.TimeLimitMinutes) * time.Minute).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// IsDataUnlimited reports whether the voucher has no data usage limit. The
+// controller omits the limit (decoded as zero) when none is set.
+func (v HotspotVoucher) IsDataUnlimited() bool {
+	return v.DataUsageLimitMB <= 0
+}
+
+// IsRateUnlimited reports whether the voucher limits neither download nor
+// upload rate
+func (v HotspotVoucher) IsRateUnlimited() bool {
+	return v.RxRateLimitKbps <= 0 && v.TxRateLimitKbps <= 0
+}
+
+// ActiveGuestCount returns the number of guests authorized by the site's
+// vouchers that have not expired
+func (c *Client) ActiveGuestCount(ctx context.Context, siteID string) (int, error) {
+	vouchers, err := c.ListAllHotspotVouchers(ctx, siteID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active guests: %w", err)
+	}
+
+	now := c.clock.Now()
+	count := 0
+	for _, voucher := range vouchers {
+		if !voucher.IsExpired(now) {
+			count += voucher.AuthorizeGuestCount
+		}
+	}
+
+	return count, nil
+}
+
 // CreateHotspotVoucher creates one or more hotspot vouchers for a site
 func (c *Client) CreateHotspotVoucher(ctx context.Context, siteID string, request *CreateHotspotVoucherRequest) (*CreateHotspotVoucherResponse, error) {
-	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers", siteID)
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers", url.PathEscape(siteID))
 
 	var response CreateHotspotVoucherResponse
 	err := c.do(ctx, http.MethodPost, urlPath, request, &response)
@@ -119,17 +229,22 @@ func (c *Client) CreateHotspotVoucher(ctx context.Context, siteID string, reques
 
 // GetHotspotVoucher retrieves a specific hotspot voucher by ID
 func (c *Client) GetHotspotVoucher(ctx context.Context, siteID, voucherID string) (*HotspotVoucher, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
 	var response struct {
 		Data []HotspotVoucher `json:"data"`
 	}
 
-	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/sites/%s/hotspot/vouchers/%s", siteID, voucherID), nil, &response)
+	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers/%s", url.PathEscape(siteID), url.PathEscape(voucherID))
+	err := c.do(ctx, http.MethodGet, urlPath, nil, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get hotspot voucher: %w", err)
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("voucher not found: %s", voucherID)
+		return nil, &NotFoundError{Resource: "voucher", ID: voucherID}
 	}
 
 	return &response.Data[0], nil
@@ -137,7 +252,12 @@ func (c *Client) GetHotspotVoucher(ctx context.Context, siteID, voucherID string
 
 // DeleteHotspotVoucher deletes a specific hotspot voucher
 func (c *Client) DeleteHotspotVoucher(ctx context.Context, siteID, voucherID string) error {
-	err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/sites/%s/hotspot/vouchers/%s", siteID, voucherID), nil, nil)
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers/%s", url.PathEscape(siteID), url.PathEscape(voucherID))
+	err := c.do(ctx, http.MethodDelete, urlPath, nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete hotspot voucher: %w", err)
 	}
@@ -147,34 +267,19 @@ func (c *Client) DeleteHotspotVoucher(ctx context.Context, siteID, voucherID str
 
 // GenerateHotspotVouchers generates one or more hotspot vouchers with the specified parameters
 func (c *Client) GenerateHotspotVouchers(ctx context.Context, siteID string, request *GenerateHotspotVouchersRequest) (*GenerateHotspotVouchersResponse, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
 	if request == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
 
-	// Validate required fields and ranges
-	if request.Name == "" {
-		return nil, fmt.Errorf("name is required")
-	}
-	if request.Count < 1 || request.Count > 10000 {
-		return nil, fmt.Errorf("count must be between 1 and 10000")
-	}
-	if request.TimeLimitMinutes < 1 || request.TimeLimitMinutes > 1000000 {
-		return nil, fmt.Errorf("timeLimitMinutes must be between 1 and 1000000")
-	}
-	if request.AuthorizeGuestLimit < 0 {
-		return nil, fmt.Errorf("authorizedGuestLimit must be greater than 0")
-	}
-	if request.DataUsageLimitMB != 0 && (request.DataUsageLimitMB < 1 || request.DataUsageLimitMB > 1046576) {
-		return nil, fmt.Errorf("dataUsageLimitMBytes must be between 1 and 1046576")
-	}
-	if request.RxRateLimitKbps != 0 && (request.RxRateLimitKbps < 2 || request.RxRateLimitKbps > 100000) {
-		return nil, fmt.Errorf("rxRateLimitKbps must be between 2 and 100000")
-	}
-	if request.TxRateLimitKbps != 0 && (request.TxRateLimitKbps < 2 || request.TxRateLimitKbps > 100000) {
-		return nil, fmt.Errorf("txRateLimitKbps must be between 2 and 100000")
+	if err := validateStruct(request); err != nil {
+		return nil, err
 	}
 
-	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers", siteID)
+	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers", url.PathEscape(siteID))
 
 	var response GenerateHotspotVouchersResponse
 	err := c.do(ctx, http.MethodPost, urlPath, request, &response)
@@ -185,16 +290,65 @@ func (c *Client) GenerateHotspotVouchers(ctx context.Context, siteID string, req
 	return &response, nil
 }
 
+// maxVoucherBatch is the most vouchers a single generate request may create.
+// It must match the max in GenerateHotspotVouchersRequest.Count's validate tag.
+const maxVoucherBatch = 10000
+
+// GenerateHotspotVouchersBatched generates total vouchers from template by
+// issuing one generate request per batch of at most batchSize, so large runs
+// do not time out in a single request. template.Count is ignored. Batches are
+// sent one after another and ctx is checked between them. If ctx carries an
+// idempotency key (see WithIdempotencyKey), batch i is sent with the key
+// suffixed by "-i", so the controller does not mistake later batches for
+// replays of the first. If a batch fails, the vouchers already generated are
+// returned along with the error, since they exist on the controller either
+// way.
+func (c *Client) GenerateHotspotVouchersBatched(ctx context.Context, siteID string, total, batchSize int, template GenerateHotspotVouchersRequest) ([]HotspotVoucher, error) {
+	if total < 1 {
+		return nil, fmt.Errorf("total must be at least 1")
+	}
+	if batchSize < 1 || batchSize > maxVoucherBatch {
+		return nil, fmt.Errorf("batchSize must be between 1 and %d", maxVoucherBatch)
+	}
+
+	key, keyed := idempotencyKeyFromContext(ctx)
+
+	// total is caller-supplied, so only the first batch is preallocated
+	vouchers := make([]HotspotVoucher, 0, min(total, batchSize))
+	for i, remaining := 0, total; remaining > 0; i++ {
+		if err := ctx.Err(); err != nil {
+			return vouchers, fmt.Errorf("failed to generate hotspot vouchers: %w", err)
+		}
+
+		batchCtx := ctx
+		if keyed {
+			batchCtx = WithIdempotencyKey(ctx, key+"-"+strconv.Itoa(i))
+		}
+
+		request := template
+		request.Count = min(remaining, batchSize)
+		resp, err := c.GenerateHotspotVouchers(batchCtx, siteID, &request)
+		if err != nil {
+			return vouchers, err
+		}
+
+		vouchers = append(vouchers, resp.Data...)
+		remaining -= request.Count
+	}
+
+	return vouchers, nil
+}
+
 // GetVoucherDetails retrieves detailed information about a specific hotspot voucher
 func (c *Client) GetVoucherDetails(ctx context.Context, siteID, voucherID string) (*HotspotVoucher, error) {
-	if siteID == "" {
-		return nil, fmt.Errorf("siteId is required")
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
 	}
 	if voucherID == "" {
 		return nil, fmt.Errorf("voucherId is required")
 	}
 
-	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers/%s", siteID, voucherID)
+	urlPath := fmt.Sprintf("/v1/sites/%s/hotspot/vouchers/%s", url.PathEscape(siteID), url.PathEscape(voucherID))
 
 	var response GetVoucherDetailsResponse
 	err := c.do(ctx, http.MethodGet, urlPath, nil, &response)
@@ -203,7 +357,7 @@ func (c *Client) GetVoucherDetails(ctx context.Context, siteID, voucherID string
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("voucher not found: %s", voucherID)
+		return nil, &NotFoundError{Resource: "voucher", ID: voucherID}
 	}
 
 	return &response.Data[0], nil
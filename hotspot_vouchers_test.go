@@ -2,7 +2,10 @@ package unifi
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func TestClient_CreateHotspotVoucher(t *testing.T) {
@@ -73,6 +76,64 @@ func TestClient_CreateHotspotVoucher(t *testing.T) {
 	})
 }
 
+func TestClient_ListHotspotVouchers_Name(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("query encoding", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListHotspotVouchersResponse{})
+
+		params := &ListHotspotVouchersParams{Limit: 50, Name: "conference 2024"}
+		if _, err := client.ListHotspotVouchers(ctx, testSiteID, params); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := mock.request.URL.RawQuery, "limit=50&name=conference+2024"; got != want {
+			t.Errorf("expected query %q, got %q", want, got)
+		}
+	})
+
+	t.Run("client-side fallback", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		// A controller that ignores the filter returns every voucher
+		mock.response = mockResponse(200, ListHotspotVouchersResponse{
+			PaginatedResponse: PaginatedResponse{Offset: 0, Count: 4, TotalCount: 4},
+			Data: []HotspotVoucher{
+				{ID: "1", Name: "Conference-2024 day 1"},
+				{ID: "2", Name: "lobby"},
+				{ID: "3", Name: "conference-2024 day 2"},
+				{ID: "4", Name: ""},
+			},
+		})
+
+		resp, err := client.ListHotspotVouchers(ctx, testSiteID, &ListHotspotVouchersParams{Name: "CONFERENCE-2024"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Data) != 2 || resp.Data[0].ID != "1" || resp.Data[1].ID != "3" {
+			t.Errorf("expected vouchers 1 and 3, got %+v", resp.Data)
+		}
+		if resp.Count != 2 || resp.TotalCount != 4 {
+			t.Errorf("expected count 2 of 4, got %d of %d", resp.Count, resp.TotalCount)
+		}
+	})
+
+	t.Run("no filter keeps every voucher", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListHotspotVouchersResponse{
+			PaginatedResponse: PaginatedResponse{Count: 2, TotalCount: 2},
+			Data:              []HotspotVoucher{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}},
+		})
+
+		resp, err := client.ListHotspotVouchers(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Data) != 2 || resp.Count != 2 {
+			t.Errorf("expected both vouchers, got %+v", resp.Data)
+		}
+	})
+}
+
 func TestClient_GetHotspotVoucher(t *testing.T) {
 	ctx := context.Background()
 	voucherID := "abc123"
@@ -124,6 +185,29 @@ func TestClient_GetHotspotVoucher(t *testing.T) {
 			t.Errorf("expected error message %q, got %q", "voucher not found: nonexistent", err.Error())
 		}
 	})
+
+	t.Run("special characters are escaped", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, struct {
+			Data []HotspotVoucher `json:"data"`
+		}{
+			Data: []HotspotVoucher{{ID: "a/b?c#d e"}},
+		})
+
+		_, err := client.GetHotspotVoucher(ctx, testSiteID, "a/b?c#d e")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := testBaseURL + "/proxy/network/integration/v1/sites/" + testSiteID + "/hotspot/vouchers/a%2Fb%3Fc%23d%20e"
+		if got := mock.request.URL.String(); got != want {
+			t.Errorf("expected URL %s, got %s", want, got)
+		}
+		if got := mock.request.URL.RawQuery; got != "" {
+			t.Errorf("expected no query string, got %q", got)
+		}
+	})
 }
 
 func TestClient_DeleteHotspotVoucher(t *testing.T) {
@@ -302,6 +386,123 @@ func TestClient_GenerateHotspotVouchers(t *testing.T) {
 	})
 }
 
+func TestClient_GenerateHotspotVouchersBatched(t *testing.T) {
+	ctx := context.Background()
+	template := GenerateHotspotVouchersRequest{Count: 9999, Name: "conference-2024", TimeLimitMinutes: 1440}
+
+	batch := func(start, n int) *http.Response {
+		vouchers := make([]HotspotVoucher, n)
+		for i := range vouchers {
+			vouchers[i] = HotspotVoucher{ID: fmt.Sprintf("v%d", start+i), Name: template.Name}
+		}
+		return mockResponse(201, GenerateHotspotVouchersResponse{Data: vouchers})
+	}
+
+	t.Run("splits into batches", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{batch(0, 100), batch(100, 100), batch(200, 50)}
+
+		vouchers, err := client.GenerateHotspotVouchersBatched(ctx, testSiteID, 250, 100, template)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(vouchers) != 250 {
+			t.Errorf("expected 250 vouchers, got %d", len(vouchers))
+		}
+		if vouchers[0].ID != "v0" || vouchers[249].ID != "v249" {
+			t.Errorf("expected vouchers in batch order, got first %s last %s", vouchers[0].ID, vouchers[249].ID)
+		}
+
+		if len(mock.requests) != 3 {
+			t.Fatalf("expected 3 generate calls, got %d", len(mock.requests))
+		}
+		for i, want := range []int{100, 100, 50} {
+			var sent GenerateHotspotVouchersRequest
+			decodeRequestBody(t, mock.requests[i], &sent)
+			if sent.Count != want || sent.Name != template.Name || sent.TimeLimitMinutes != template.TimeLimitMinutes {
+				t.Errorf("call %d: expected count %d from template, got %+v", i, want, sent)
+			}
+		}
+	})
+
+	t.Run("idempotency key differs per batch", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{batch(0, 100), batch(100, 100), batch(200, 50)}
+
+		keyed := WithIdempotencyKey(ctx, "run-1")
+		if _, err := client.GenerateHotspotVouchersBatched(keyed, testSiteID, 250, 100, template); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mock.requests) != 3 {
+			t.Fatalf("expected 3 generate calls, got %d", len(mock.requests))
+		}
+		for i, want := range []string{"run-1-0", "run-1-1", "run-1-2"} {
+			if got := mock.requests[i].Header.Get(idempotencyKeyHeader); got != want {
+				t.Errorf("call %d: expected Idempotency-Key %s, got %q", i, want, got)
+			}
+		}
+	})
+
+	t.Run("failed batch returns vouchers so far", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			batch(0, 100),
+			mockResponse(500, Error{Status: 500, Message: "Internal Server Error"}),
+		}
+
+		vouchers, err := client.GenerateHotspotVouchersBatched(ctx, testSiteID, 250, 100, template)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(vouchers) != 100 {
+			t.Errorf("expected the 100 vouchers from the first batch, got %d", len(vouchers))
+		}
+		if len(mock.requests) != 2 {
+			t.Errorf("expected no calls after the failure, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := client.GenerateHotspotVouchersBatched(cancelled, testSiteID, 250, 100, template)
+		if !IsCanceled(err) {
+			t.Errorf("expected cancellation error, got %v", err)
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("invalid sizes", func(t *testing.T) {
+		tests := []struct {
+			name             string
+			total, batchSize int
+		}{
+			{"zero total", 0, 100},
+			{"zero batch", 250, 0},
+			{"negative batch", 250, -1},
+			{"batch too large", 20000, 10001},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client, mock := newTestClient(t, testBaseURL)
+
+				if _, err := client.GenerateHotspotVouchersBatched(ctx, testSiteID, tt.total, tt.batchSize, template); err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if len(mock.requests) != 0 {
+					t.Errorf("expected no requests, got %d", len(mock.requests))
+				}
+			})
+		}
+	})
+}
+
 func TestClient_GetVoucherDetails(t *testing.T) {
 	ctx := context.Background()
 	voucherID := "4997eeca-0276-4993-bfeb-53cbbbaa4f00"
@@ -416,3 +617,123 @@ func TestClient_GetVoucherDetails(t *testing.T) {
 		assertErrorResponse(t, err, 404, "Voucher not found")
 	})
 }
+
+func TestClient_ActiveGuestCount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sums non-expired vouchers across pages", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		clock := newFakeClock() // 2024-01-01T00:00:00Z
+		client.clock = clock
+
+		mock.responses = []*http.Response{
+			mockResponse(200, ListHotspotVouchersResponse{
+				PaginatedResponse: PaginatedResponse{Offset: 0, Limit: 200, Count: 3, TotalCount: 5},
+				Data: []HotspotVoucher{
+					{ID: "active", AuthorizeGuestCount: 2, ExpiresAt: "2024-01-02T00:00:00Z"},
+					{ID: "flagged-expired", AuthorizeGuestCount: 4, Expired: true},
+					{ID: "no-expiry", AuthorizeGuestCount: 1},
+				},
+			}),
+			mockResponse(200, ListHotspotVouchersResponse{
+				PaginatedResponse: PaginatedResponse{Offset: 3, Limit: 200, Count: 2, TotalCount: 5},
+				Data: []HotspotVoucher{
+					{ID: "past-expiry", AuthorizeGuestCount: 3, ExpiresAt: "2023-12-31T23:59:59Z"},
+					{ID: "unused", AuthorizeGuestCount: 0, ExpiresAt: "2024-06-01T00:00:00Z"},
+				},
+			}),
+		}
+
+		count, err := client.ActiveGuestCount(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if count != 3 {
+			t.Errorf("expected 3 active guests, got %d", count)
+		}
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+		}
+		if got := mock.requests[1].URL.Query().Get("offset"); got != "3" {
+			t.Errorf("expected second page at offset 3, got %q", got)
+		}
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(500, Error{Status: 500, StatusName: "Internal Server Error", Message: "boom"})
+
+		_, err := client.ActiveGuestCount(ctx, testSiteID)
+		assertErrorResponse(t, err, 500, "boom")
+	})
+}
+
+func TestHotspotVoucher_Unlimited(t *testing.T) {
+	tests := []struct {
+		name          string
+		voucher       HotspotVoucher
+		dataUnlimited bool
+		rateUnlimited bool
+	}{
+		{name: "no limits", voucher: HotspotVoucher{}, dataUnlimited: true, rateUnlimited: true},
+		{name: "data limit", voucher: HotspotVoucher{DataUsageLimitMB: 500}, dataUnlimited: false, rateUnlimited: true},
+		{name: "download limit only", voucher: HotspotVoucher{RxRateLimitKbps: 2048}, dataUnlimited: true, rateUnlimited: false},
+		{name: "upload limit only", voucher: HotspotVoucher{TxRateLimitKbps: 512}, dataUnlimited: true, rateUnlimited: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.voucher.IsDataUnlimited(); got != tt.dataUnlimited {
+				t.Errorf("IsDataUnlimited() = %v, want %v", got, tt.dataUnlimited)
+			}
+			if got := tt.voucher.IsRateUnlimited(); got != tt.rateUnlimited {
+				t.Errorf("IsRateUnlimited() = %v, want %v", got, tt.rateUnlimited)
+			}
+		})
+	}
+}
+
+func TestHotspotVoucher_RemainingTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		voucher HotspotVoucher
+		want    time.Duration
+	}{
+		{
+			name:    "activated with time left",
+			voucher: HotspotVoucher{ActivatedAt: "2024-01-01T11:00:00Z", TimeLimitMinutes: 120},
+			want:    time.Hour,
+		},
+		{
+			name:    "time limit used up",
+			voucher: HotspotVoucher{ActivatedAt: "2024-01-01T10:00:00Z", TimeLimitMinutes: 60},
+			want:    0,
+		},
+		{
+			name:    "flagged expired",
+			voucher: HotspotVoucher{ActivatedAt: "2024-01-01T11:00:00Z", TimeLimitMinutes: 120, Expired: true},
+			want:    0,
+		},
+		{
+			name:    "never activated",
+			voucher: HotspotVoucher{TimeLimitMinutes: 120},
+			want:    0,
+		},
+		{
+			name:    "malformed activation time",
+			voucher: HotspotVoucher{ActivatedAt: "yesterday", TimeLimitMinutes: 120},
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.voucher.RemainingTime(now); got != tt.want {
+				t.Errorf("RemainingTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// paginate yields every item from successive pages returned by fetch,
+// starting at offset (or cursor, if set), until a page is empty or total is
+// reached. Once a page carries a next cursor, later pages are requested by
+// cursor instead of offset and the sequence ends with the first page that
+// has none, so both pagination models are followed. Each page is requested
+// only once the previous one has been consumed. An error is yielded once and
+// ends the sequence.
+func paginate[T any](offset int, cursor string, fetch func(offset int, cursor string) (items []T, total int, next string, err error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			items, total, next, err := fetch(offset, cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			offset += len(items)
+			switch {
+			case len(items) == 0, next != "" && next == cursor:
+				return
+			case next != "":
+				cursor = next
+			case cursor != "", offset >= total:
+				return
+			}
+		}
+	}
+}
+
+// collect drains seq into a slice, stopping at the first error
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// pageStart returns the offset and cursor to request a page with. A cursor
+// already identifies the position, so the offset is dropped alongside it.
+func pageStart(offset int, cursor string) (int, string) {
+	if cursor != "" {
+		return 0, cursor
+	}
+	return offset, ""
+}
+
+// IterDevices returns an iterator over every device on a site, fetching pages
+// lazily as the loop advances. params may set a Type filter, a starting
+// Offset and a page size; a nil params or zero Limit uses LimitMax.
+func (c *Client) IterDevices(ctx context.Context, siteID string, params *ListDevicesParams) iter.Seq2[Device, error] {
+	page := ListDevicesParams{Limit: LimitMax}
+	if params != nil {
+		page = *params
+		if page.Limit == 0 {
+			page.Limit = LimitMax
+		}
+	}
+
+	return paginate(page.Offset, page.Cursor, func(offset int, cursor string) ([]Device, int, string, error) {
+		page.Offset, page.Cursor = pageStart(offset, cursor)
+		resp, err := c.ListDevices(ctx, siteID, &page)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		return resp.Data, resp.TotalCount, resp.NextPageCursor(), nil
+	})
+}
+
+// IterSites returns an iterator over every site, fetching pages lazily. See
+// IterDevices for how params are used.
+func (c *Client) IterSites(ctx context.Context, params *ListSitesParams) iter.Seq2[Site, error] {
+	page := ListSitesParams{Limit: LimitMax}
+	if params != nil {
+		page = *params
+		if page.Limit == 0 {
+			page.Limit = LimitMax
+		}
+	}
+
+	return paginate(page.Offset, page.Cursor, func(offset int, cursor string) ([]Site, int, string, error) {
+		page.Offset, page.Cursor = pageStart(offset, cursor)
+		resp, err := c.ListSites(ctx, &page)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		return resp.Data, resp.TotalCount, resp.NextPageCursor(), nil
+	})
+}
+
+// IterNetworkClients returns an iterator over every network client on a site,
+// fetching pages lazily. See IterDevices for how params are used.
+func (c *Client) IterNetworkClients(ctx context.Context, siteID string, params *ListNetworkClientsParams) iter.Seq2[NetworkClient, error] {
+	page := ListNetworkClientsParams{Limit: LimitMax}
+	if params != nil {
+		page = *params
+		if page.Limit == 0 {
+			page.Limit = LimitMax
+		}
+	}
+
+	return paginate(page.Offset, page.Cursor, func(offset int, cursor string) ([]NetworkClient, int, string, error) {
+		page.Offset, page.Cursor = pageStart(offset, cursor)
+		resp, err := c.ListNetworkClients(ctx, siteID, &page)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		return resp.Data, resp.TotalCount, resp.NextPageCursor(), nil
+	})
+}
+
+// IterHotspotVouchers returns an iterator over every hotspot voucher on a
+// site, fetching pages lazily. See IterDevices for how params are used; a
+// Name filter is applied as in ListHotspotVouchers.
+func (c *Client) IterHotspotVouchers(ctx context.Context, siteID string, params *ListHotspotVouchersParams) iter.Seq2[HotspotVoucher, error] {
+	page := ListHotspotVouchersParams{Limit: LimitMax}
+	if params != nil {
+		page = *params
+		if page.Limit == 0 {
+			page.Limit = LimitMax
+		}
+	}
+
+	// Pages are fetched unfiltered by the client so offsets follow what the
+	// controller returned, whether or not it applied the name filter
+	vouchers := paginate(page.Offset, page.Cursor, func(offset int, cursor string) ([]HotspotVoucher, int, string, error) {
+		page.Offset, page.Cursor = pageStart(offset, cursor)
+		query, err := buildQuery(&page, c.maxPageLimit)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		resp, err := c.listHotspotVouchers(ctx, siteID, query)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		return resp.Data, resp.TotalCount, resp.NextPageCursor(), nil
+	})
+	if page.Name == "" {
+		return vouchers
+	}
+
+	return func(yield func(HotspotVoucher, error) bool) {
+		for v, err := range vouchers {
+			if err == nil && !v.matchesName(page.Name) {
+				continue
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// ForEachVoucher calls fn for every hotspot voucher on a site, fetching pages
+// lazily so only one page is held in memory at a time. It stops at the first
+// error from fn and returns it unchanged, and stops with the context error
+// once ctx is done, even part way through a page.
+func (c *Client) ForEachVoucher(ctx context.Context, siteID string, fn func(HotspotVoucher) error) error {
+	for v, err := range c.IterHotspotVouchers(ctx, siteID, nil) {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("failed to iterate hotspot vouchers: %w", err)
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
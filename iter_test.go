@@ -0,0 +1,351 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_IterDevices(t *testing.T) {
+	ctx := context.Background()
+
+	pages := func() []*http.Response {
+		return []*http.Response{
+			mockResponse(200, ListDevicesResponse{
+				PaginatedResponse: PaginatedResponse{Count: 2, TotalCount: 3},
+				Data:              []Device{{ID: "d1"}, {ID: "d2"}},
+			}),
+			mockResponse(200, ListDevicesResponse{
+				PaginatedResponse: PaginatedResponse{Offset: 2, Count: 1, TotalCount: 3},
+				Data:              []Device{{ID: "d3"}},
+			}),
+		}
+	}
+
+	t.Run("follows pagination", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = pages()
+
+		var ids []string
+		for device, err := range client.IterDevices(ctx, testSiteID, &ListDevicesParams{Type: DeviceTypeAccessPoint}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids = append(ids, device.ID)
+		}
+
+		if want := []string{"d1", "d2", "d3"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("expected devices %v, got %v", want, ids)
+		}
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+		}
+		second := mock.requests[1].URL.Query()
+		if second.Get("offset") != "2" || second.Get("limit") != "200" || second.Get("type") != DeviceTypeAccessPoint {
+			t.Errorf("unexpected second page query: %v", second)
+		}
+	})
+
+	t.Run("pages are fetched lazily", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = pages()
+
+		for range client.IterDevices(ctx, testSiteID, nil) {
+			break
+		}
+		if len(mock.requests) != 1 {
+			t.Errorf("expected 1 request after stopping early, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("error ends the sequence", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = pages()[:1]
+		mock.response = mockResponse(500, Error{Status: 500, Message: "Internal Server Error"})
+
+		var seen int
+		var lastErr error
+		for _, err := range client.IterDevices(ctx, testSiteID, nil) {
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			seen++
+		}
+		if seen != 2 {
+			t.Errorf("expected 2 devices before the error, got %d", seen)
+		}
+		assertErrorResponse(t, lastErr, 500, "Internal Server Error")
+	})
+}
+
+func TestClient_IterCursorPagination(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("follows nextCursor across pages", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			mockRawResponse(200, `{"data":[{"_id":"d1"},{"_id":"d2"}],"nextCursor":"abc"}`),
+			mockRawResponse(200, `{"data":[{"_id":"d3"}]}`),
+		}
+
+		var ids []string
+		for device, err := range client.IterDevices(ctx, testSiteID, &ListDevicesParams{Type: DeviceTypeAccessPoint}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids = append(ids, device.ID)
+		}
+
+		if want := []string{"d1", "d2", "d3"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("expected devices %v, got %v", want, ids)
+		}
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+		}
+		if first := mock.requests[0].URL.Query(); first.Has("cursor") {
+			t.Errorf("first page should not send a cursor: %v", first)
+		}
+		second := mock.requests[1].URL.Query()
+		if second.Get("cursor") != "abc" || second.Has("offset") || second.Get("type") != DeviceTypeAccessPoint {
+			t.Errorf("unexpected second page query: %v", second)
+		}
+	})
+
+	t.Run("accepts cursor field name", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			mockRawResponse(200, `{"data":[{"id":"s1"}],"cursor":"p2"}`),
+			mockRawResponse(200, `{"data":[{"id":"s2"}],"cursor":""}`),
+		}
+
+		sites, err := client.ListAllSites(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(sites) != 2 {
+			t.Fatalf("expected 2 sites, got %d", len(sites))
+		}
+		if got := mock.requests[1].URL.Query().Get("cursor"); got != "p2" {
+			t.Errorf("expected cursor p2, got %q", got)
+		}
+	})
+
+	t.Run("starts from params cursor", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":[{"id":"c1"}]}`)
+
+		for _, err := range client.IterNetworkClients(ctx, testSiteID, &ListNetworkClientsParams{Cursor: "resume"}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if len(mock.requests) != 1 {
+			t.Fatalf("expected 1 request, got %d", len(mock.requests))
+		}
+		if got := mock.requests[0].URL.Query().Get("cursor"); got != "resume" {
+			t.Errorf("expected cursor resume, got %q", got)
+		}
+	})
+
+	t.Run("repeated cursor stops", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			mockRawResponse(200, `{"data":[{"id":"v1"}],"nextCursor":"same"}`),
+			mockRawResponse(200, `{"data":[{"id":"v2"}],"nextCursor":"same"}`),
+		}
+
+		vouchers, err := client.ListAllHotspotVouchers(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.requests) != 2 || len(vouchers) != 2 {
+			t.Errorf("expected to stop after the cursor repeated, got %d requests and %d vouchers", len(mock.requests), len(vouchers))
+		}
+	})
+}
+
+func TestClient_IterSitesClientsVouchers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sites", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListSitesResponse{Count: 1, TotalCount: 1, Data: []Site{{ID: "default"}}})
+
+		var got []Site
+		for site, err := range client.IterSites(ctx, nil) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, site)
+		}
+		if len(got) != 1 || got[0].ID != "default" {
+			t.Errorf("unexpected sites: %v", got)
+		}
+	})
+
+	t.Run("network clients", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListNetworkClientsResponse{Count: 1, TotalCount: 1, Data: []NetworkClient{{ID: "c1"}}})
+
+		var got []NetworkClient
+		for nc, err := range client.IterNetworkClients(ctx, testSiteID, &ListNetworkClientsParams{Limit: 50}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, nc)
+		}
+		if len(got) != 1 || got[0].ID != "c1" {
+			t.Errorf("unexpected clients: %v", got)
+		}
+		if limit := mock.request.URL.Query().Get("limit"); limit != "50" {
+			t.Errorf("expected page size 50, got %q", limit)
+		}
+	})
+
+	t.Run("hotspot vouchers", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListHotspotVouchersResponse{
+			PaginatedResponse: PaginatedResponse{Count: 0, TotalCount: 0},
+		})
+
+		for _, err := range client.IterHotspotVouchers(ctx, testSiteID, nil) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			t.Error("expected no vouchers")
+		}
+	})
+
+	t.Run("hotspot vouchers by name", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		// The controller ignores the filter and pages through every voucher
+		mock.responses = []*http.Response{
+			mockResponse(200, ListHotspotVouchersResponse{
+				PaginatedResponse: PaginatedResponse{Offset: 0, Count: 2, TotalCount: 3},
+				Data:              []HotspotVoucher{{ID: "1", Name: "vip"}, {ID: "2", Name: "lobby"}},
+			}),
+			mockResponse(200, ListHotspotVouchersResponse{
+				PaginatedResponse: PaginatedResponse{Offset: 2, Count: 1, TotalCount: 3},
+				Data:              []HotspotVoucher{{ID: "3", Name: "VIP guests"}},
+			}),
+		}
+
+		var got []string
+		for v, err := range client.IterHotspotVouchers(ctx, testSiteID, &ListHotspotVouchersParams{Limit: 2, Name: "vip"}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, v.ID)
+		}
+		if !reflect.DeepEqual(got, []string{"1", "3"}) {
+			t.Errorf("expected vouchers [1 3], got %v", got)
+		}
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+		}
+		if got := mock.requests[1].URL.Query(); got.Get("offset") != "2" || got.Get("name") != "vip" {
+			t.Errorf("expected second page at offset 2 with name=vip, got %v", got)
+		}
+	})
+}
+
+func TestClient_ForEachVoucher(t *testing.T) {
+	ctx := context.Background()
+
+	pages := func() []*http.Response {
+		return []*http.Response{
+			mockResponse(200, ListHotspotVouchersResponse{
+				PaginatedResponse: PaginatedResponse{Offset: 0, Count: 2, TotalCount: 4},
+				Data:              []HotspotVoucher{{ID: "v1"}, {ID: "v2"}},
+			}),
+			mockResponse(200, ListHotspotVouchersResponse{
+				PaginatedResponse: PaginatedResponse{Offset: 2, Count: 2, TotalCount: 4},
+				Data:              []HotspotVoucher{{ID: "v3"}, {ID: "v4"}},
+			}),
+		}
+	}
+
+	t.Run("visits every voucher", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = pages()
+
+		var seen []string
+		err := client.ForEachVoucher(ctx, testSiteID, func(v HotspotVoucher) error {
+			seen = append(seen, v.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(seen, []string{"v1", "v2", "v3", "v4"}) {
+			t.Errorf("unexpected vouchers: %v", seen)
+		}
+	})
+
+	t.Run("stops on callback error", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = pages()
+		errStop := errors.New("stop")
+
+		var seen []string
+		err := client.ForEachVoucher(ctx, testSiteID, func(v HotspotVoucher) error {
+			seen = append(seen, v.ID)
+			if len(seen) == 3 {
+				return errStop
+			}
+			return nil
+		})
+		if err != errStop {
+			t.Errorf("expected the callback error, got %v", err)
+		}
+		if !reflect.DeepEqual(seen, []string{"v1", "v2", "v3"}) {
+			t.Errorf("expected iteration to stop after v3, got %v", seen)
+		}
+		if len(mock.requests) != 2 {
+			t.Errorf("expected 2 page requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = pages()
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var seen []string
+		err := client.ForEachVoucher(cancelCtx, testSiteID, func(v HotspotVoucher) error {
+			seen = append(seen, v.ID)
+			cancel()
+			return nil
+		})
+		if !IsCanceled(err) {
+			t.Errorf("expected cancellation error, got %v", err)
+		}
+		if !reflect.DeepEqual(seen, []string{"v1"}) {
+			t.Errorf("expected only v1 before cancellation, got %v", seen)
+		}
+		if len(mock.requests) != 1 {
+			t.Errorf("expected no further page requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(500, Error{Status: 500, Message: "boom"})
+
+		called := false
+		err := client.ForEachVoucher(ctx, testSiteID, func(HotspotVoucher) error {
+			called = true
+			return nil
+		})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if called {
+			t.Error("expected callback not to be called")
+		}
+	})
+}
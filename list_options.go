@@ -0,0 +1,117 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Sort orders accepted by SortBy
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// ListOptions holds the options shared by list operations. It is built from
+// ListOption values rather than filled in directly.
+type ListOptions struct {
+	Offset    int    // Starting offset
+	Limit     int    // [0..200] or LimitMax; 0 uses the controller default
+	SortField string // Field to sort by
+	SortOrder string // SortAscending or SortDescending
+}
+
+// ListOption configures a list request made through one of the *Opts methods
+type ListOption func(*ListOptions)
+
+// Limit sets the page size. Use LimitMax for the largest page the controller allows.
+func Limit(n int) ListOption {
+	return func(o *ListOptions) {
+		o.Limit = n
+	}
+}
+
+// Offset sets the starting offset
+func Offset(n int) ListOption {
+	return func(o *ListOptions) {
+		o.Offset = n
+	}
+}
+
+// SortBy sorts results by field in the given order (SortAscending or
+// SortDescending). An empty order leaves the choice to the controller.
+func SortBy(field, order string) ListOption {
+	return func(o *ListOptions) {
+		o.SortField = field
+		o.SortOrder = order
+	}
+}
+
+// listQuery applies opts and encodes them as query parameters
+func listQuery(opts []ListOption, maxLimit int) (url.Values, error) {
+	var options ListOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	query := url.Values{}
+	if err := setPagination(query, options.Offset, options.Limit, maxLimit); err != nil {
+		return nil, err
+	}
+
+	switch options.SortOrder {
+	case "", SortAscending, SortDescending:
+	default:
+		return nil, fmt.Errorf("sort order must be %q or %q", SortAscending, SortDescending)
+	}
+	if options.SortField != "" {
+		query.Set("sort", options.SortField)
+		if options.SortOrder != "" {
+			query.Set("order", options.SortOrder)
+		}
+	} else if options.SortOrder != "" {
+		return nil, fmt.Errorf("sort field is required when a sort order is set")
+	}
+
+	return query, nil
+}
+
+// ListSitesOpts is like ListSites but takes functional list options
+func (c *Client) ListSitesOpts(ctx context.Context, opts ...ListOption) (*ListSitesResponse, error) {
+	query, err := listQuery(opts, c.maxPageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listSites(ctx, query)
+}
+
+// ListDevicesOpts is like ListDevices but takes functional list options
+func (c *Client) ListDevicesOpts(ctx context.Context, siteID string, opts ...ListOption) (*ListDevicesResponse, error) {
+	query, err := listQuery(opts, c.maxPageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listDevices(ctx, siteID, query)
+}
+
+// ListNetworkClientsOpts is like ListNetworkClients but takes functional list options
+func (c *Client) ListNetworkClientsOpts(ctx context.Context, siteID string, opts ...ListOption) (*ListNetworkClientsResponse, error) {
+	query, err := listQuery(opts, c.maxPageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listNetworkClients(ctx, siteID, query)
+}
+
+// ListHotspotVouchersOpts is like ListHotspotVouchers but takes functional list options
+func (c *Client) ListHotspotVouchersOpts(ctx context.Context, siteID string, opts ...ListOption) (*ListHotspotVouchersResponse, error) {
+	query, err := listQuery(opts, c.maxPageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listHotspotVouchers(ctx, siteID, query)
+}
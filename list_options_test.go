@@ -0,0 +1,95 @@
+package unifi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestListQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ListOption
+		want    string
+		wantErr bool
+	}{
+		{name: "no options", want: ""},
+		{name: "limit only", opts: []ListOption{Limit(10)}, want: "limit=10"},
+		{name: "limit max", opts: []ListOption{Limit(LimitMax)}, want: "limit=200"},
+		{
+			name: "all options combined",
+			opts: []ListOption{Offset(50), Limit(25), SortBy("name", SortDescending)},
+			want: "limit=25&offset=50&order=desc&sort=name",
+		},
+		{name: "sort without order", opts: []ListOption{SortBy("name", "")}, want: "sort=name"},
+		{name: "later option wins", opts: []ListOption{Limit(10), Limit(20)}, want: "limit=20"},
+		{name: "limit too large", opts: []ListOption{Limit(MaxPageLimit + 1)}, wantErr: true},
+		{name: "invalid sort order", opts: []ListOption{SortBy("name", "up")}, wantErr: true},
+		{name: "order without field", opts: []ListOption{SortBy("", SortAscending)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := listQuery(tt.opts, MaxPageLimit)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := query.Encode(); got != tt.want {
+				t.Errorf("expected query %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClient_ListDevicesOpts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("options are encoded", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListDevicesResponse{
+			PaginatedResponse: PaginatedResponse{Offset: 10, Limit: 5, Count: 1, TotalCount: 11},
+			Data:              []Device{{ID: "device1"}},
+		})
+
+		result, err := client.ListDevicesOpts(ctx, testSiteID, Limit(5), Offset(10), SortBy("name", SortAscending))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := mock.request.URL.RawQuery; got != "limit=5&offset=10&order=asc&sort=name" {
+			t.Errorf("unexpected query string: %s", got)
+		}
+		if len(result.Data) != 1 || result.Data[0].ID != "device1" {
+			t.Errorf("unexpected devices: %+v", result.Data)
+		}
+	})
+
+	t.Run("invalid option is rejected before sending", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		if _, err := client.ListDevicesOpts(ctx, testSiteID, Limit(500)); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+}
+
+func TestClient_ListSitesOpts(t *testing.T) {
+	client, mock := newTestClient(t, testBaseURL)
+	mock.response = mockResponse(200, ListSitesResponse{Data: []Site{{ID: "default"}}})
+
+	if _, err := client.ListSitesOpts(context.Background(), Offset(25), Limit(LimitMax)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := mock.request.URL.RawQuery; got != "limit=200&offset=25" {
+		t.Errorf("unexpected query string: %s", got)
+	}
+}
@@ -0,0 +1,28 @@
+package unifi
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NormalizeMAC converts a 48-bit MAC address in any common notation
+// (00:11:22:33:44:55, 00-11-22-33-44-55, 0011.2233.4455 or 001122334455)
+// to the lowercase, colon-separated form the controller uses
+func NormalizeMAC(mac string) (string, error) {
+	s := strings.TrimSpace(mac)
+	if len(s) == 12 && !strings.ContainsAny(s, ":-.") {
+		parts := make([]string, 0, 6)
+		for i := 0; i < len(s); i += 2 {
+			parts = append(parts, s[i:i+2])
+		}
+		s = strings.Join(parts, ":")
+	}
+
+	hw, err := net.ParseMAC(s)
+	if err != nil || len(hw) != 6 {
+		return "", fmt.Errorf("invalid MAC address: %q", mac)
+	}
+
+	return hw.String(), nil
+}
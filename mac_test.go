@@ -0,0 +1,40 @@
+package unifi
+
+import "testing"
+
+func TestNormalizeMAC(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "00:11:22:aa:bb:cc", want: "00:11:22:aa:bb:cc"},
+		{in: "00:11:22:AA:BB:CC", want: "00:11:22:aa:bb:cc"},
+		{in: "00-11-22-AA-BB-CC", want: "00:11:22:aa:bb:cc"},
+		{in: "0011.22aa.bbcc", want: "00:11:22:aa:bb:cc"},
+		{in: "001122AABBCC", want: "00:11:22:aa:bb:cc"},
+		{in: " 00:11:22:aa:bb:cc ", want: "00:11:22:aa:bb:cc"},
+		{in: "", wantErr: true},
+		{in: "00:11:22:aa:bb", wantErr: true},
+		{in: "00112233445566778899", wantErr: true},
+		{in: "zz:11:22:aa:bb:cc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizeMAC(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NormalizeMAC(%q) = %q, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeMAC(%q) unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeMAC(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
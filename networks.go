@@ -0,0 +1,43 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Network represents a configured network/VLAN (networkconf) on a site
+type Network struct {
+	ID          string `json:"_id"`           // Unique identifier
+	Name        string `json:"name"`          // Network name
+	Purpose     string `json:"purpose"`       // Network purpose (corporate, guest, wan, vlan-only, remote-user-vpn)
+	VLANEnabled bool   `json:"vlan_enabled"`  // Whether the network is tagged with a VLAN
+	VLAN        int    `json:"vlan"`          // VLAN ID, if VLANEnabled
+	Subnet      string `json:"ip_subnet"`     // Gateway IP and subnet in CIDR notation (e.g., 192.168.1.1/24)
+	DHCPEnabled bool   `json:"dhcpd_enabled"` // Whether the DHCP server is enabled
+	DomainName  string `json:"domain_name"`   // DHCP domain name
+	Enabled     bool   `json:"enabled"`       // Whether the network is enabled
+}
+
+// ListNetworks retrieves the networks/VLANs configured for a site
+func (c *Client) ListNetworks(ctx context.Context, siteID string) ([]Network, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data []Network `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/networks", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodGet, urlPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	if response.Data == nil {
+		return []Network{}, nil
+	}
+
+	return response.Data, nil
+}
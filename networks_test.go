@@ -0,0 +1,111 @@
+package unifi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_ListNetworks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockRawResponse(200, `{"meta":{"rc":"ok"},"data":[
+			{
+				"_id": "net1",
+				"name": "Default",
+				"purpose": "corporate",
+				"ip_subnet": "192.168.1.1/24",
+				"dhcpd_enabled": true,
+				"dhcpd_start": "192.168.1.6",
+				"dhcpd_stop": "192.168.1.254",
+				"domain_name": "localdomain",
+				"enabled": true,
+				"site_id": "default"
+			},
+			{
+				"_id": "net2",
+				"name": "IoT",
+				"purpose": "corporate",
+				"vlan_enabled": true,
+				"vlan": 20,
+				"ip_subnet": "10.0.20.1/24",
+				"dhcpd_enabled": false,
+				"enabled": true
+			}
+		]}`)
+
+		networks, err := client.ListNetworks(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/"+testSiteID+"/networks" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+
+		want := []Network{
+			{
+				ID:          "net1",
+				Name:        "Default",
+				Purpose:     "corporate",
+				Subnet:      "192.168.1.1/24",
+				DHCPEnabled: true,
+				DomainName:  "localdomain",
+				Enabled:     true,
+			},
+			{
+				ID:          "net2",
+				Name:        "IoT",
+				Purpose:     "corporate",
+				VLANEnabled: true,
+				VLAN:        20,
+				Subnet:      "10.0.20.1/24",
+				Enabled:     true,
+			},
+		}
+		if len(networks) != len(want) {
+			t.Fatalf("expected %d networks, got %d", len(want), len(networks))
+		}
+		for i := range want {
+			if networks[i] != want[i] {
+				t.Errorf("network %d: expected %+v, got %+v", i, want[i], networks[i])
+			}
+		}
+	})
+
+	t.Run("no networks", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":null}`)
+
+		networks, err := client.ListNetworks(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if networks == nil || len(networks) != 0 {
+			t.Errorf("expected empty non-nil slice, got %#v", networks)
+		}
+	})
+
+	t.Run("empty site ID", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if _, err := client.ListNetworks(ctx, ""); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(404, Error{
+			Status:     404,
+			StatusName: "Not Found",
+			Message:    "Site not found",
+		})
+
+		_, err := client.ListNetworks(ctx, "nonexistent")
+		assertErrorResponse(t, err, 404, "Site not found")
+	})
+}
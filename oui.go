@@ -0,0 +1,84 @@
+package unifi
+
+// ouiVendors maps the OUI prefix of a MAC address (the first three octets,
+// lowercase and colon-separated) to its manufacturer. It deliberately covers
+// only vendors commonly seen on UniFi networks rather than the full IEEE
+// registry, so unknown prefixes are expected.
+var ouiVendors = map[string]string{
+	// Ubiquiti
+	"00:15:6d": "Ubiquiti",
+	"00:27:22": "Ubiquiti",
+	"04:18:d6": "Ubiquiti",
+	"18:e8:29": "Ubiquiti",
+	"24:5a:4c": "Ubiquiti",
+	"24:a4:3c": "Ubiquiti",
+	"44:d9:e7": "Ubiquiti",
+	"68:d7:9a": "Ubiquiti",
+	"74:83:c2": "Ubiquiti",
+	"78:8a:20": "Ubiquiti",
+	"80:2a:a8": "Ubiquiti",
+	"b4:fb:e4": "Ubiquiti",
+	"dc:9f:db": "Ubiquiti",
+	"e0:63:da": "Ubiquiti",
+	"f0:9f:c2": "Ubiquiti",
+	"fc:ec:da": "Ubiquiti",
+
+	// Apple
+	"00:03:93": "Apple",
+	"00:17:f2": "Apple",
+	"00:1b:63": "Apple",
+	"00:25:00": "Apple",
+	"28:cf:e9": "Apple",
+	"3c:07:54": "Apple",
+	"a4:5e:60": "Apple",
+	"ac:bc:32": "Apple",
+	"f0:18:98": "Apple",
+
+	// Raspberry Pi
+	"28:cd:c1": "Raspberry Pi",
+	"b8:27:eb": "Raspberry Pi",
+	"d8:3a:dd": "Raspberry Pi",
+	"dc:a6:32": "Raspberry Pi",
+	"e4:5f:01": "Raspberry Pi",
+
+	// Virtual machines
+	"00:05:69": "VMware",
+	"00:0c:29": "VMware",
+	"00:50:56": "VMware",
+	"00:15:5d": "Microsoft",
+	"52:54:00": "QEMU",
+
+	// Other common client vendors
+	"00:00:0c": "Cisco",
+	"00:0e:58": "Sonos",
+	"00:14:22": "Dell",
+	"00:17:88": "Philips",
+	"18:b4:30": "Nest",
+	"24:0a:c4": "Espressif",
+	"30:ae:a4": "Espressif",
+	"3c:5a:b4": "Google",
+	"5c:aa:fd": "Sonos",
+	"b8:e9:37": "Sonos",
+	"f4:f5:d8": "Google",
+}
+
+// LookupVendor returns the manufacturer for a MAC address using the built-in
+// OUI table, or an empty string if the address is invalid or its prefix is
+// not known
+func LookupVendor(mac string) string {
+	normalized, err := NormalizeMAC(mac)
+	if err != nil {
+		return ""
+	}
+	return ouiVendors[normalized[:8]]
+}
+
+// Vendor returns the manufacturer of the client's network interface, resolved
+// from its MAC address. If the prefix is not in the built-in table, the OUI
+// reported by the controller is returned as-is, which may also be empty.
+func (nc NetworkClient) Vendor() string {
+	if vendor := LookupVendor(nc.MACAddress); vendor != "" {
+		return vendor
+	}
+	return nc.OUI
+}
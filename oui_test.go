@@ -0,0 +1,47 @@
+package unifi
+
+import "testing"
+
+func TestLookupVendor(t *testing.T) {
+	tests := []struct {
+		name string
+		mac  string
+		want string
+	}{
+		{name: "known prefix", mac: "24:A4:3C:12:34:56", want: "Ubiquiti"},
+		{name: "dashed notation", mac: "b8-27-eb-00-11-22", want: "Raspberry Pi"},
+		{name: "unknown prefix", mac: "02:00:00:00:00:01", want: ""},
+		{name: "invalid MAC", mac: "not-a-mac", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LookupVendor(tt.mac); got != tt.want {
+				t.Errorf("LookupVendor(%q) = %q, want %q", tt.mac, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetworkClient_Vendor(t *testing.T) {
+	t.Run("resolved from MAC", func(t *testing.T) {
+		nc := NetworkClient{MACAddress: "00:50:56:aa:bb:cc"}
+		if got := nc.Vendor(); got != "VMware" {
+			t.Errorf("expected VMware, got %q", got)
+		}
+	})
+
+	t.Run("falls back to controller OUI", func(t *testing.T) {
+		nc := NetworkClient{MACAddress: "02:00:00:00:00:01", OUI: "Acme"}
+		if got := nc.Vendor(); got != "Acme" {
+			t.Errorf("expected Acme, got %q", got)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		nc := NetworkClient{MACAddress: "02:00:00:00:00:01"}
+		if got := nc.Vendor(); got != "" {
+			t.Errorf("expected empty vendor, got %q", got)
+		}
+	})
+}
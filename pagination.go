@@ -0,0 +1,72 @@
+package unifi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// MaxPageLimit is the largest page size the controller accepts for list endpoints
+const MaxPageLimit = 200
+
+// LimitMax can be used as the Limit in any list params struct to request the
+// largest page the controller allows (MaxPageLimit, or the client's
+// WithMaxPageLimitOverride). A Limit of 0 leaves the choice to the
+// controller, which uses its default page size of 25.
+const LimitMax = -1
+
+// WithMaxPageLimitOverride raises (or lowers) the largest page size list
+// methods accept, and the page size LimitMax requests, from MaxPageLimit to
+// n. Only use it with controller builds known to accept larger pages; most
+// reject or silently truncate anything above MaxPageLimit.
+func WithMaxPageLimitOverride(n int) ClientOption {
+	return func(c *Client) {
+		c.maxPageLimit = n
+	}
+}
+
+// setPagination adds offset and limit query parameters, translating LimitMax
+// and validating the limit against maxLimit
+func setPagination(query url.Values, offset, limit, maxLimit int) error {
+	if offset > 0 {
+		query.Set("offset", fmt.Sprint(offset))
+	}
+
+	switch {
+	case limit == LimitMax:
+		query.Set("limit", fmt.Sprint(maxLimit))
+	case limit < LimitMax:
+		return fmt.Errorf("limit must be -1 (LimitMax) or between 0 and %d", maxLimit)
+	case limit > maxLimit:
+		return fmt.Errorf("limit must be between 0 and %d", maxLimit)
+	case limit > 0:
+		query.Set("limit", fmt.Sprint(limit))
+	}
+
+	return nil
+}
+
+// firstCursor returns the first non-empty next page token
+func firstCursor(cursors ...string) string {
+	for _, c := range cursors {
+		if c != "" {
+			return c
+		}
+	}
+	return ""
+}
+
+// DecodePage decodes the raw Data of a paginated response into a typed slice.
+// An empty or null Data decodes to an empty slice.
+func DecodePage[T any](resp PaginatedResponse) ([]T, error) {
+	items := []T{}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return items, nil
+	}
+
+	if err := json.Unmarshal(resp.Data, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode page data: %w", err)
+	}
+
+	return items, nil
+}
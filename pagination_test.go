@@ -0,0 +1,200 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestDecodePage(t *testing.T) {
+	t.Run("devices", func(t *testing.T) {
+		var page PaginatedResponse
+		err := json.Unmarshal([]byte(`{
+			"offset": 0,
+			"limit": 25,
+			"count": 2,
+			"totalCount": 2,
+			"data": [
+				{"_id": "device1", "name": "Office AP", "model": "U6-Pro"},
+				{"_id": "device2", "name": "Core Switch", "model": "USW-24"}
+			]
+		}`), &page)
+		if err != nil {
+			t.Fatalf("failed to unmarshal page: %v", err)
+		}
+
+		devices, err := DecodePage[Device](page)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(devices) != 2 {
+			t.Fatalf("expected 2 devices, got %d", len(devices))
+		}
+		if devices[0].ID != "device1" || devices[0].Model != "U6-Pro" {
+			t.Errorf("unexpected first device: %+v", devices[0])
+		}
+		if devices[1].Name != "Core Switch" {
+			t.Errorf("expected second device name %q, got %q", "Core Switch", devices[1].Name)
+		}
+	})
+
+	t.Run("sites", func(t *testing.T) {
+		page := PaginatedResponse{
+			Count: 1,
+			Data:  json.RawMessage(`[{"id": "default", "name": "Default"}]`),
+		}
+
+		sites, err := DecodePage[Site](page)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(sites) != 1 || sites[0] != (Site{ID: "default", Name: "Default"}) {
+			t.Errorf("unexpected sites: %+v", sites)
+		}
+	})
+
+	t.Run("empty data", func(t *testing.T) {
+		for _, data := range []json.RawMessage{nil, json.RawMessage(`null`), json.RawMessage(`[]`)} {
+			sites, err := DecodePage[Site](PaginatedResponse{Data: data})
+			if err != nil {
+				t.Fatalf("unexpected error for %q: %v", data, err)
+			}
+			if sites == nil || len(sites) != 0 {
+				t.Errorf("expected empty non-nil slice for %q, got %#v", data, sites)
+			}
+		}
+	})
+
+	t.Run("mismatched data", func(t *testing.T) {
+		_, err := DecodePage[Site](PaginatedResponse{Data: json.RawMessage(`{"id": "default"}`)})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestWithMaxPageLimitOverride(t *testing.T) {
+	ctx := context.Background()
+
+	newOverrideClient := func(t *testing.T, limit int) (*Client, *mockTransport) {
+		t.Helper()
+		mock := &mockTransport{response: mockResponse(200, ListDevicesResponse{})}
+		client, err := NewClient(testBaseURL,
+			WithAPIKey("test-api-key"),
+			WithHTTPClient(&http.Client{Transport: mock}),
+			WithLogger(quietLogger()),
+			WithMaxPageLimitOverride(limit),
+		)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		return client, mock
+	}
+
+	t.Run("default rejects limit above 200", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		if _, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Limit: 400}); err == nil {
+			t.Fatal("expected error for limit 400, got nil")
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("override permits larger limit", func(t *testing.T) {
+		client, mock := newOverrideClient(t, 500)
+
+		if _, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Limit: 400}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := mock.request.URL.Query().Get("limit"); got != "400" {
+			t.Errorf("expected limit 400, got %q", got)
+		}
+
+		if _, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Limit: 501}); err == nil {
+			t.Error("expected error for limit above override, got nil")
+		}
+	})
+
+	t.Run("LimitMax uses override", func(t *testing.T) {
+		client, mock := newOverrideClient(t, 500)
+
+		if _, err := client.ListSitesOpts(ctx, Limit(LimitMax)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := mock.request.URL.Query().Get("limit"); got != "500" {
+			t.Errorf("expected limit 500, got %q", got)
+		}
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		for _, n := range []int{0, -1} {
+			if _, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithMaxPageLimitOverride(n)); err == nil {
+				t.Errorf("expected error for override %d, got nil", n)
+			}
+		}
+	})
+}
+
+func TestListLimitValidation(t *testing.T) {
+	ctx := context.Background()
+
+	list := map[string]func(c *Client, limit int) error{
+		"devices": func(c *Client, limit int) error {
+			_, err := c.ListDevices(ctx, testSiteID, &ListDevicesParams{Limit: limit})
+			return err
+		},
+		"clients": func(c *Client, limit int) error {
+			_, err := c.ListNetworkClients(ctx, testSiteID, &ListNetworkClientsParams{Limit: limit})
+			return err
+		},
+		"sites": func(c *Client, limit int) error {
+			_, err := c.ListSites(ctx, &ListSitesParams{Limit: limit})
+			return err
+		},
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit string
+		wantErr   string
+	}{
+		{name: "max sentinel", limit: LimitMax, wantLimit: "200"},
+		{name: "controller default", limit: 0},
+		{name: "explicit", limit: 50, wantLimit: "50"},
+		{name: "over maximum", limit: 201, wantErr: "limit must be between 0 and 200"},
+		{name: "just below sentinel", limit: -2, wantErr: "limit must be -1 (LimitMax) or between 0 and 200"},
+		{name: "negative", limit: -5, wantErr: "limit must be -1 (LimitMax) or between 0 and 200"},
+	}
+
+	for endpoint, call := range list {
+		for _, tt := range tests {
+			t.Run(endpoint+"/"+tt.name, func(t *testing.T) {
+				client, mock := newTestClient(t, testBaseURL)
+				mock.response = mockResponse(200, map[string]interface{}{"data": []interface{}{}})
+
+				err := call(client, tt.limit)
+				if tt.wantErr != "" {
+					if err == nil || err.Error() != tt.wantErr {
+						t.Fatalf("error = %v, want %q", err, tt.wantErr)
+					}
+					if len(mock.requests) != 0 {
+						t.Errorf("expected no requests, got %d", len(mock.requests))
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := mock.request.URL.Query().Get("limit"); got != tt.wantLimit {
+					t.Errorf("limit = %q, want %q", got, tt.wantLimit)
+				}
+			})
+		}
+	}
+}
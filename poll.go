@@ -0,0 +1,36 @@
+package unifi
+
+import (
+	"context"
+	"time"
+)
+
+// poll calls fn every interval on the client's clock until it reports done,
+// returns an error, or ctx is done. fn is called once immediately.
+func (c *Client) poll(ctx context.Context, interval time.Duration, fn func() (done bool, err error)) error {
+	return c.pollWithBackoff(ctx, interval, interval, fn)
+}
+
+// pollWithBackoff is like poll but doubles the wait after each unsuccessful
+// attempt, starting at interval and capped at maxInterval
+func (c *Client) pollWithBackoff(ctx context.Context, interval, maxInterval time.Duration, fn func() (done bool, err error)) error {
+	wait := interval
+	for {
+		done, err := fn()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		if err := c.sleep(ctx, wait); err != nil {
+			return err
+		}
+
+		wait *= 2
+		if wait > maxInterval {
+			wait = maxInterval
+		}
+	}
+}
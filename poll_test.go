@@ -0,0 +1,113 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_poll(t *testing.T) {
+	ctx := context.Background()
+
+	newPollClient := func(t *testing.T) (*Client, *fakeClock) {
+		client, _ := newTestClient(t, testBaseURL)
+		clock := newFakeClock()
+		clock.autoAdvance = true
+		client.clock = clock
+		return client, clock
+	}
+
+	t.Run("immediate success", func(t *testing.T) {
+		client, clock := newPollClient(t)
+
+		calls := 0
+		err := client.poll(ctx, time.Second, func() (bool, error) {
+			calls++
+			return true, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 call, got %d", calls)
+		}
+		if len(clock.Sleeps()) != 0 {
+			t.Errorf("expected no sleeps, got %v", clock.Sleeps())
+		}
+	})
+
+	t.Run("eventual success", func(t *testing.T) {
+		client, clock := newPollClient(t)
+
+		calls := 0
+		err := client.poll(ctx, time.Second, func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 calls, got %d", calls)
+		}
+		want := []time.Duration{time.Second, time.Second}
+		if got := clock.Sleeps(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected sleeps %v, got %v", want, got)
+		}
+	})
+
+	t.Run("backoff is capped", func(t *testing.T) {
+		client, clock := newPollClient(t)
+
+		calls := 0
+		err := client.pollWithBackoff(ctx, time.Second, 5*time.Second, func() (bool, error) {
+			calls++
+			return calls == 5, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+		if got := clock.Sleeps(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected sleeps %v, got %v", want, got)
+		}
+	})
+
+	t.Run("error propagation", func(t *testing.T) {
+		client, _ := newPollClient(t)
+
+		wantErr := errors.New("device not found")
+		calls := 0
+		err := client.poll(ctx, time.Second, func() (bool, error) {
+			calls++
+			if calls == 2 {
+				return false, wantErr
+			}
+			return false, nil
+		})
+		if !errors.Is(err, wantErr) {
+			t.Errorf("expected %v, got %v", wantErr, err)
+		}
+		if calls != 2 {
+			t.Errorf("expected 2 calls, got %d", calls)
+		}
+	})
+
+	t.Run("context timeout", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+		clock := newFakeClock()
+		client.clock = clock
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		err := client.poll(ctx, time.Hour, func() (bool, error) {
+			return false, nil
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
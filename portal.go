@@ -0,0 +1,108 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// hexColorPattern matches CSS hex colors in #rgb or #rrggbb form
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// PortalTheme holds the colors used by the guest portal page
+type PortalTheme struct {
+	BackgroundColor string `json:"portal_customized_bg_color,omitempty"`          // Page background
+	TextColor       string `json:"portal_customized_text_color,omitempty"`        // Body text
+	LinkColor       string `json:"portal_customized_link_color,omitempty"`        // Links
+	BoxColor        string `json:"portal_customized_box_color,omitempty"`         // Login box background
+	ButtonColor     string `json:"portal_customized_button_color,omitempty"`      // Button background
+	ButtonTextColor string `json:"portal_customized_button_text_color,omitempty"` // Button text
+}
+
+// colors returns the theme colors with a readable name for error messages
+func (t PortalTheme) colors() []struct{ name, value string } {
+	return []struct{ name, value string }{
+		{"background color", t.BackgroundColor},
+		{"text color", t.TextColor},
+		{"link color", t.LinkColor},
+		{"box color", t.BoxColor},
+		{"button color", t.ButtonColor},
+		{"button text color", t.ButtonTextColor},
+	}
+}
+
+// PortalSettings represents the customization of a site's guest portal page
+type PortalSettings struct {
+	Title        string `json:"portal_customized_title"`                  // Page title
+	WelcomeText  string `json:"portal_customized_welcome_text,omitempty"` // Text shown above the login form
+	TermsEnabled bool   `json:"portal_customized_tos_enabled"`            // Whether guests must accept Terms
+	Terms        string `json:"portal_customized_tos,omitempty"`          // Terms of service text
+	PortalTheme
+}
+
+// validate checks the settings before they are sent to the controller
+func (p *PortalSettings) validate() error {
+	if p.Title == "" {
+		return fmt.Errorf("portal title is required")
+	}
+	if p.TermsEnabled && p.Terms == "" {
+		return fmt.Errorf("terms text is required when terms are enabled")
+	}
+	for _, color := range p.colors() {
+		if color.value != "" && !hexColorPattern.MatchString(color.value) {
+			return fmt.Errorf("invalid %s %q: must be a hex color like #1a2b3c", color.name, color.value)
+		}
+	}
+	return nil
+}
+
+// GetPortalSettings retrieves a site's guest portal customization
+func (c *Client) GetPortalSettings(ctx context.Context, siteID string) (*PortalSettings, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data []PortalSettings `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/portal", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodGet, urlPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get portal settings: %w", err)
+	}
+
+	if len(response.Data) == 0 {
+		return nil, &NotFoundError{Resource: "portal settings", ID: siteID}
+	}
+
+	return &response.Data[0], nil
+}
+
+// UpdatePortalSettings replaces a site's guest portal customization and
+// returns the settings the controller stored. Fetch the current settings with
+// GetPortalSettings first to change individual fields.
+func (c *Client) UpdatePortalSettings(ctx context.Context, siteID string, settings PortalSettings) (*PortalSettings, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data []PortalSettings `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/portal", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodPut, urlPath, &settings, &response); err != nil {
+		return nil, fmt.Errorf("failed to update portal settings: %w", err)
+	}
+
+	if len(response.Data) == 0 {
+		return &settings, nil
+	}
+
+	return &response.Data[0], nil
+}
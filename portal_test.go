@@ -0,0 +1,152 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_GetPortalSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{
+			"data": []map[string]interface{}{{
+				"portal_customized_title":         "Welcome to Acme",
+				"portal_customized_welcome_text":  "Free Wi-Fi for visitors",
+				"portal_customized_tos_enabled":   true,
+				"portal_customized_tos":           "Be nice.",
+				"portal_customized_bg_color":      "#ffffff",
+				"portal_customized_button_color":  "#0066cc",
+				"portal_customized_text_color":    "#333",
+				"portal_customized_unknown_field": "ignored",
+			}},
+		})
+
+		settings, err := client.GetPortalSettings(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := PortalSettings{
+			Title:        "Welcome to Acme",
+			WelcomeText:  "Free Wi-Fi for visitors",
+			TermsEnabled: true,
+			Terms:        "Be nice.",
+			PortalTheme: PortalTheme{
+				BackgroundColor: "#ffffff",
+				ButtonColor:     "#0066cc",
+				TextColor:       "#333",
+			},
+		}
+		if *settings != want {
+			t.Errorf("expected %+v, got %+v", want, *settings)
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/default/portal" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{"data": []interface{}{}})
+
+		if _, err := client.GetPortalSettings(ctx, testSiteID); !IsNotFound(err) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestClient_UpdatePortalSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("request body", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		settings := PortalSettings{
+			Title:       "Guest Wi-Fi",
+			WelcomeText: "Hello",
+			PortalTheme: PortalTheme{LinkColor: "#aa00ff"},
+		}
+		mock.response = mockResponse(200, map[string]interface{}{"data": []PortalSettings{settings}})
+
+		updated, err := client.UpdatePortalSettings(ctx, testSiteID, settings)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *updated != settings {
+			t.Errorf("expected %+v, got %+v", settings, *updated)
+		}
+
+		if mock.request.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", mock.request.Method)
+		}
+		var sent map[string]interface{}
+		decodeRequestBody(t, mock.request, &sent)
+		want := map[string]interface{}{
+			"portal_customized_title":        "Guest Wi-Fi",
+			"portal_customized_welcome_text": "Hello",
+			"portal_customized_tos_enabled":  false,
+			"portal_customized_link_color":   "#aa00ff",
+		}
+		if len(sent) != len(want) {
+			t.Errorf("expected %d fields, got %v", len(want), sent)
+		}
+		for key, value := range want {
+			if sent[key] != value {
+				t.Errorf("expected %s=%v, got %v", key, value, sent[key])
+			}
+		}
+	})
+
+	t.Run("empty response returns sent settings", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{"data": []interface{}{}})
+
+		settings := PortalSettings{Title: "Guest Wi-Fi"}
+		updated, err := client.UpdatePortalSettings(ctx, testSiteID, settings)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *updated != settings {
+			t.Errorf("expected %+v, got %+v", settings, *updated)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			settings PortalSettings
+			wantErr  bool
+		}{
+			{name: "title only", settings: PortalSettings{Title: "Guests"}},
+			{name: "short hex color", settings: PortalSettings{Title: "Guests", PortalTheme: PortalTheme{TextColor: "#FFF"}}},
+			{name: "missing title", settings: PortalSettings{WelcomeText: "Hello"}, wantErr: true},
+			{name: "terms enabled without text", settings: PortalSettings{Title: "Guests", TermsEnabled: true}, wantErr: true},
+			{name: "named color", settings: PortalSettings{Title: "Guests", PortalTheme: PortalTheme{BackgroundColor: "red"}}, wantErr: true},
+			{name: "missing hash", settings: PortalSettings{Title: "Guests", PortalTheme: PortalTheme{ButtonColor: "0066cc"}}, wantErr: true},
+			{name: "bad hex digits", settings: PortalSettings{Title: "Guests", PortalTheme: PortalTheme{BoxColor: "#gggggg"}}, wantErr: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client, mock := newTestClient(t, testBaseURL)
+				mock.response = mockResponse(200, map[string]interface{}{"data": []PortalSettings{tt.settings}})
+
+				_, err := client.UpdatePortalSettings(ctx, testSiteID, tt.settings)
+				if tt.wantErr {
+					if err == nil {
+						t.Fatal("expected error, got nil")
+					}
+					if len(mock.requests) != 0 {
+						t.Errorf("expected no requests, got %d", len(mock.requests))
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package unifi
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// buildQuery encodes a list params struct as query parameters using the
+// field's json tag as the parameter name. Zero values and fields tagged "-"
+// are skipped, offset and limit go through setPagination with maxLimit, and
+// time.Time is sent as milliseconds since epoch. A nil params yields an empty
+// query. Escaping is left to url.Values.Encode.
+func buildQuery(params interface{}, maxLimit int) (url.Values, error) {
+	query := url.Values{}
+
+	v := reflect.ValueOf(params)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return query, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query params must be a struct, got %s", v.Kind())
+	}
+
+	var offset, limit int
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || name == "" || !field.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+		switch name {
+		case "offset":
+			offset = int(fv.Int())
+			continue
+		case "limit":
+			limit = int(fv.Int())
+			continue
+		}
+		if fv.IsZero() {
+			continue
+		}
+
+		switch {
+		case fv.Type() == timeType:
+			query.Set(name, fmt.Sprint(fv.Interface().(time.Time).UnixMilli()))
+		case fv.Kind() == reflect.String:
+			query.Set(name, fv.String())
+		case fv.Kind() == reflect.Bool,
+			fv.Kind() >= reflect.Int && fv.Kind() <= reflect.Uint64:
+			query.Set(name, fmt.Sprint(fv.Interface()))
+		default:
+			return nil, fmt.Errorf("unsupported query parameter type %s for %q", fv.Type(), name)
+		}
+	}
+
+	if err := setPagination(query, offset, limit, maxLimit); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
@@ -0,0 +1,88 @@
+package unifi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBuildQuery(t *testing.T) {
+	t.Run("nil params", func(t *testing.T) {
+		query, err := buildQuery((*ListDevicesParams)(nil), MaxPageLimit)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(query) != 0 {
+			t.Errorf("expected empty query, got %v", query)
+		}
+	})
+
+	t.Run("skips zero values and ignored fields", func(t *testing.T) {
+		query, err := buildQuery(&ListDevicesParams{Strict: true}, MaxPageLimit)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := query.Encode(); got != "" {
+			t.Errorf("expected empty query, got %q", got)
+		}
+	})
+
+	t.Run("pagination and times", func(t *testing.T) {
+		start := time.UnixMilli(1700000000000)
+		query, err := buildQuery(&EventParams{Limit: LimitMax, Start: start}, MaxPageLimit)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := query.Encode(), "limit=200&start=1700000000000"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("limit too large", func(t *testing.T) {
+		if _, err := buildQuery(&ListSitesParams{Limit: MaxPageLimit + 1}, MaxPageLimit); err == nil {
+			t.Error("expected error for limit above MaxPageLimit")
+		}
+	})
+
+	t.Run("non-struct params", func(t *testing.T) {
+		if _, err := buildQuery("type=uap", MaxPageLimit); err == nil {
+			t.Error("expected error for non-struct params")
+		}
+	})
+}
+
+func TestQueryEscaping(t *testing.T) {
+	ctx := context.Background()
+	const value = "Lobby & Hall=1 #2?"
+	const encoded = "Lobby+%26+Hall%3D1+%232%3F"
+
+	t.Run("device type", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListDevicesResponse{})
+
+		if _, err := client.ListDevices(ctx, testSiteID, &ListDevicesParams{Type: value}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := mock.request.URL.RawQuery, "type="+encoded; got != want {
+			t.Errorf("expected raw query %q, got %q", want, got)
+		}
+		if got := mock.request.URL.Query().Get("type"); got != value {
+			t.Errorf("expected type %q to round-trip, got %q", value, got)
+		}
+	})
+
+	t.Run("event severity", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListDeviceEventsResponse{})
+
+		if _, err := client.ListDeviceEvents(ctx, testSiteID, &EventParams{Severity: value}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := mock.request.URL.RawQuery, "severity="+encoded; got != want {
+			t.Errorf("expected raw query %q, got %q", want, got)
+		}
+		if got := mock.request.URL.Query().Get("severity"); got != value {
+			t.Errorf("expected severity %q to round-trip, got %q", value, got)
+		}
+	})
+}
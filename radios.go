@@ -0,0 +1,174 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Radio bands as reported in a device's radio table
+const (
+	RadioBand2G = "ng" // 2.4 GHz
+	RadioBand5G = "na" // 5 GHz
+	RadioBand6G = "6e" // 6 GHz
+)
+
+// RadioConfig is the configuration of one radio on an access point
+type RadioConfig struct {
+	Name        string `json:"name"`          // Interface name (wifi0, wifi1, ...)
+	Band        string `json:"radio"`         // Band: ng, na or 6e
+	Channel     int    `json:"channel"`       // Configured channel, 0 for auto
+	Width       int    `json:"ht"`            // Channel width in MHz
+	TxPowerMode string `json:"tx_power_mode"` // auto, high, medium, low or custom
+	TxPower     int    `json:"tx_power"`      // Transmit power in dBm when tx_power_mode is custom
+}
+
+// UnmarshalJSON accepts channel, width and tx power as numbers or strings,
+// since controllers report "auto" channels and some firmware quotes numbers.
+func (r *RadioConfig) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name        string `json:"name"`
+		Band        string `json:"radio"`
+		Channel     any    `json:"channel"`
+		Width       any    `json:"ht"`
+		TxPowerMode string `json:"tx_power_mode"`
+		TxPower     any    `json:"tx_power"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = RadioConfig{Name: raw.Name, Band: raw.Band, TxPowerMode: raw.TxPowerMode}
+	for _, f := range []struct {
+		name string
+		in   any
+		out  *int
+	}{
+		{"channel", raw.Channel, &r.Channel},
+		{"ht", raw.Width, &r.Width},
+		{"tx_power", raw.TxPower, &r.TxPower},
+	} {
+		n, err := radioInt(f.in)
+		if err != nil {
+			return fmt.Errorf("invalid radio %s: %w", f.name, err)
+		}
+		*f.out = n
+	}
+	return nil
+}
+
+// radioInt converts a radio table value to an int, treating missing values
+// and "auto" as 0
+func radioInt(v any) (int, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return int(v), nil
+	case string:
+		if v == "" || v == "auto" {
+			return 0, nil
+		}
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("unexpected type %T", v)
+	}
+}
+
+// validateRadioChannel checks that channel is usable on band. 0 selects
+// automatic channel selection and is valid on every band.
+func validateRadioChannel(band string, channel int) error {
+	if channel == 0 {
+		return nil
+	}
+
+	var ok bool
+	switch band {
+	case RadioBand2G:
+		ok = channel >= 1 && channel <= 14
+	case RadioBand5G:
+		ok = (channel >= 36 && channel <= 64 || channel >= 100 && channel <= 144) && channel%4 == 0 ||
+			channel >= 149 && channel <= 165 && channel%4 == 1
+	case RadioBand6G:
+		ok = channel >= 1 && channel <= 233 && channel%4 == 1
+	default:
+		return fmt.Errorf("unknown radio band %q (want %s, %s or %s)", band, RadioBand2G, RadioBand5G, RadioBand6G)
+	}
+
+	if !ok {
+		return fmt.Errorf("channel %d is not valid for band %s", channel, band)
+	}
+	return nil
+}
+
+// GetDeviceRadios returns the radio configuration of an access point. Devices
+// without radios return an empty slice.
+func (c *Client) GetDeviceRadios(ctx context.Context, siteID, deviceID string) ([]RadioConfig, error) {
+	_, raw, err := c.GetDeviceRaw(ctx, siteID, deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device radios: %w", err)
+	}
+
+	var device struct {
+		RadioTable []RadioConfig `json:"radio_table"`
+	}
+	if err := json.Unmarshal(raw, &device); err != nil {
+		return nil, fmt.Errorf("failed to decode device radios: %w", err)
+	}
+
+	return device.RadioTable, nil
+}
+
+// SetRadioChannel sets the channel of the radio on band, where 0 selects
+// automatic channel selection. The rest of the device's radio table is sent
+// back unchanged, including fields RadioConfig does not model.
+func (c *Client) SetRadioChannel(ctx context.Context, siteID, deviceID, band string, channel int) error {
+	if err := validateRadioChannel(band, channel); err != nil {
+		return err
+	}
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+	if deviceID == "" {
+		return fmt.Errorf("deviceId is required")
+	}
+
+	_, raw, err := c.GetDeviceRaw(ctx, siteID, deviceID)
+	if err != nil {
+		return fmt.Errorf("failed to set radio channel: %w", err)
+	}
+
+	var device struct {
+		RadioTable []map[string]any `json:"radio_table"`
+	}
+	if err := json.Unmarshal(raw, &device); err != nil {
+		return fmt.Errorf("failed to decode device radios: %w", err)
+	}
+
+	found := false
+	for _, radio := range device.RadioTable {
+		if radio["radio"] != band {
+			continue
+		}
+		if channel == 0 {
+			radio["channel"] = "auto"
+		} else {
+			radio["channel"] = channel
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("device %s has no %s radio", deviceID, band)
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/devices/%s", url.PathEscape(siteID), url.PathEscape(deviceID))
+	update := map[string]any{"radio_table": device.RadioTable}
+	if err := c.do(ctx, http.MethodPatch, urlPath, update, nil); err != nil {
+		return fmt.Errorf("failed to set radio channel: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const radioDeviceJSON = `{"data":[{"_id":"ap1","type":"uap","radio_table":[
+	{"name":"wifi0","radio":"ng","channel":6,"ht":20,"tx_power_mode":"auto","min_rssi_enabled":true},
+	{"name":"wifi1","radio":"na","channel":"auto","ht":"80","tx_power_mode":"custom","tx_power":"17"}
+]}]}`
+
+func TestClient_GetDeviceRadios(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("decodes radio table", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, radioDeviceJSON)
+
+		radios, err := client.GetDeviceRadios(ctx, testSiteID, "ap1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []RadioConfig{
+			{Name: "wifi0", Band: RadioBand2G, Channel: 6, Width: 20, TxPowerMode: "auto"},
+			{Name: "wifi1", Band: RadioBand5G, Channel: 0, Width: 80, TxPowerMode: "custom", TxPower: 17},
+		}
+		if !reflect.DeepEqual(radios, want) {
+			t.Errorf("radios = %+v, want %+v", radios, want)
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/default/devices/ap1" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+	})
+
+	t.Run("device without radios", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":[{"_id":"sw1","type":"usw"}]}`)
+
+		radios, err := client.GetDeviceRadios(ctx, testSiteID, "sw1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(radios) != 0 {
+			t.Errorf("expected no radios, got %+v", radios)
+		}
+	})
+
+	t.Run("invalid channel value", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":[{"_id":"ap1","radio_table":[{"radio":"ng","channel":"six"}]}]}`)
+
+		if _, err := client.GetDeviceRadios(ctx, testSiteID, "ap1"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestClient_SetRadioChannel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("patches matching radio only", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			mockRawResponse(200, radioDeviceJSON),
+			mockResponse(200, nil),
+		}
+
+		if err := client.SetRadioChannel(ctx, testSiteID, "ap1", RadioBand5G, 149); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected get and patch requests, got %d", len(mock.requests))
+		}
+		patch := mock.requests[1]
+		if patch.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", patch.Method)
+		}
+
+		var body struct {
+			RadioTable []map[string]interface{} `json:"radio_table"`
+		}
+		decodeRequestBody(t, patch, &body)
+		if len(body.RadioTable) != 2 {
+			t.Fatalf("expected full radio table, got %v", body.RadioTable)
+		}
+		if got := body.RadioTable[0]; got["channel"] != float64(6) || got["min_rssi_enabled"] != true {
+			t.Errorf("2.4 GHz radio should be unchanged, got %v", got)
+		}
+		if got := body.RadioTable[1]["channel"]; got != float64(149) {
+			t.Errorf("expected 5 GHz channel 149, got %v", got)
+		}
+	})
+
+	t.Run("auto channel", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			mockRawResponse(200, radioDeviceJSON),
+			mockResponse(200, nil),
+		}
+
+		if err := client.SetRadioChannel(ctx, testSiteID, "ap1", RadioBand2G, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var body struct {
+			RadioTable []map[string]interface{} `json:"radio_table"`
+		}
+		decodeRequestBody(t, mock.requests[1], &body)
+		if got := body.RadioTable[0]["channel"]; got != "auto" {
+			t.Errorf(`expected channel "auto", got %v`, got)
+		}
+	})
+
+	t.Run("band missing on device", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{mockRawResponse(200, radioDeviceJSON)}
+
+		err := client.SetRadioChannel(ctx, testSiteID, "ap1", RadioBand6G, 37)
+		if err == nil || !strings.Contains(err.Error(), "has no 6e radio") {
+			t.Fatalf("expected missing band error, got %v", err)
+		}
+		if len(mock.requests) != 1 {
+			t.Errorf("expected no update request, got %d requests", len(mock.requests))
+		}
+	})
+
+	t.Run("invalid channel makes no request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		if err := client.SetRadioChannel(ctx, testSiteID, "ap1", RadioBand2G, 36); err == nil {
+			t.Error("expected error, got nil")
+		}
+		if len(mock.requests) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.requests))
+		}
+	})
+}
+
+func TestValidateRadioChannel(t *testing.T) {
+	tests := []struct {
+		band    string
+		valid   []int
+		invalid []int
+	}{
+		{band: RadioBand2G, valid: []int{0, 1, 6, 11, 14}, invalid: []int{-1, 15, 36}},
+		{band: RadioBand5G, valid: []int{0, 36, 64, 100, 144, 149, 165}, invalid: []int{6, 37, 68, 96, 148, 169}},
+		{band: RadioBand6G, valid: []int{0, 1, 5, 37, 233}, invalid: []int{2, 36, 237}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.band, func(t *testing.T) {
+			for _, ch := range tt.valid {
+				if err := validateRadioChannel(tt.band, ch); err != nil {
+					t.Errorf("channel %d: unexpected error: %v", ch, err)
+				}
+			}
+			for _, ch := range tt.invalid {
+				if err := validateRadioChannel(tt.band, ch); err == nil {
+					t.Errorf("channel %d: expected error, got nil", ch)
+				}
+			}
+		})
+	}
+
+	t.Run("unknown band", func(t *testing.T) {
+		if err := validateRadioChannel("60g", 1); err == nil || !strings.Contains(err.Error(), "unknown radio band") {
+			t.Errorf("expected unknown band error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,225 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// RADIUS tunnel attributes used to assign an account to a VLAN
+const (
+	RadiusTunnelTypeVLAN      = 13 // Tunnel-Type VLAN
+	RadiusTunnelMediumType802 = 6  // Tunnel-Medium-Type IEEE-802
+)
+
+// Limits applied to RADIUS credentials on create
+const (
+	radiusUsernameMaxLen     = 64
+	radiusPasswordMinLen     = 8
+	radiusPasswordMinClasses = 3 // Character classes required out of lower, upper, digit, symbol
+)
+
+// RadiusAccount represents a user profile on the controller's built-in
+// RADIUS server, used for WPA-Enterprise and VPN authentication
+type RadiusAccount struct {
+	ID               string `json:"_id,omitempty"`                // Unique identifier
+	Name             string `json:"name"`                         // Username
+	Password         string `json:"x_password,omitempty"`         // Password; only sent, never returned by the controller
+	VLAN             int    `json:"vlan,omitempty"`               // VLAN assigned on successful authentication
+	TunnelType       int    `json:"tunnel_type,omitempty"`        // RADIUS Tunnel-Type, RadiusTunnelTypeVLAN when VLAN is set
+	TunnelMediumType int    `json:"tunnel_medium_type,omitempty"` // RADIUS Tunnel-Medium-Type, RadiusTunnelMediumType802 when VLAN is set
+}
+
+// validateRadiusUsername rejects usernames the RADIUS server cannot match reliably
+func validateRadiusUsername(name string) error {
+	if name == "" {
+		return fmt.Errorf("username is required")
+	}
+	if len(name) > radiusUsernameMaxLen {
+		return fmt.Errorf("username must be at most %d characters", radiusUsernameMaxLen)
+	}
+	if strings.IndexFunc(name, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return fmt.Errorf("invalid username %q: must not contain whitespace or control characters", name)
+	}
+	return nil
+}
+
+// validateRadiusPassword requires a minimum length and a mix of character classes
+func validateRadiusPassword(password string) error {
+	if len(password) < radiusPasswordMinLen {
+		return fmt.Errorf("password must be at least %d characters", radiusPasswordMinLen)
+	}
+
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	if lower+upper+digit+symbol < radiusPasswordMinClasses {
+		return fmt.Errorf("password must contain at least %d of: lowercase letters, uppercase letters, digits, symbols", radiusPasswordMinClasses)
+	}
+	return nil
+}
+
+// ListRadiusAccounts retrieves the RADIUS user accounts configured for a site
+func (c *Client) ListRadiusAccounts(ctx context.Context, siteID string) ([]RadiusAccount, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data []RadiusAccount `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/radius/accounts", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodGet, urlPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list RADIUS accounts: %w", err)
+	}
+
+	if response.Data == nil {
+		return []RadiusAccount{}, nil
+	}
+
+	return response.Data, nil
+}
+
+// GetRadiusAccount retrieves a specific RADIUS account by ID
+func (c *Client) GetRadiusAccount(ctx context.Context, siteID, accountID string) (*RadiusAccount, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+	if accountID == "" {
+		return nil, fmt.Errorf("accountId is required")
+	}
+
+	var response struct {
+		Data []RadiusAccount `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/radius/accounts/%s", url.PathEscape(siteID), url.PathEscape(accountID))
+	if err := c.do(ctx, http.MethodGet, urlPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get RADIUS account: %w", err)
+	}
+
+	if len(response.Data) == 0 {
+		return nil, &NotFoundError{Resource: "RADIUS account", ID: accountID}
+	}
+
+	return &response.Data[0], nil
+}
+
+// CreateRadiusAccount creates a RADIUS account after validating the username
+// and password strength. If VLAN is set and the tunnel attributes are not,
+// they are filled in so the VLAN assignment takes effect.
+func (c *Client) CreateRadiusAccount(ctx context.Context, siteID string, account *RadiusAccount) (*RadiusAccount, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account cannot be nil")
+	}
+	if err := validateRadiusUsername(account.Name); err != nil {
+		return nil, err
+	}
+	if err := validateRadiusPassword(account.Password); err != nil {
+		return nil, err
+	}
+
+	request := withVLANTunnel(*account)
+
+	var response struct {
+		Data []RadiusAccount `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/radius/accounts", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodPost, urlPath, &request, &response); err != nil {
+		return nil, fmt.Errorf("failed to create RADIUS account: %w", err)
+	}
+
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("failed to create RADIUS account: empty response")
+	}
+
+	return &response.Data[0], nil
+}
+
+// UpdateRadiusAccount replaces a RADIUS account. An empty Password keeps the
+// existing one; a new password is validated like on create.
+func (c *Client) UpdateRadiusAccount(ctx context.Context, siteID, accountID string, account *RadiusAccount) (*RadiusAccount, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+	if accountID == "" {
+		return nil, fmt.Errorf("accountId is required")
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account cannot be nil")
+	}
+	if err := validateRadiusUsername(account.Name); err != nil {
+		return nil, err
+	}
+	if account.Password != "" {
+		if err := validateRadiusPassword(account.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	request := withVLANTunnel(*account)
+	request.ID = accountID
+
+	var response struct {
+		Data []RadiusAccount `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/radius/accounts/%s", url.PathEscape(siteID), url.PathEscape(accountID))
+	if err := c.do(ctx, http.MethodPut, urlPath, &request, &response); err != nil {
+		return nil, fmt.Errorf("failed to update RADIUS account: %w", err)
+	}
+
+	if len(response.Data) == 0 {
+		return nil, &NotFoundError{Resource: "RADIUS account", ID: accountID}
+	}
+
+	return &response.Data[0], nil
+}
+
+// DeleteRadiusAccount deletes a RADIUS account
+func (c *Client) DeleteRadiusAccount(ctx context.Context, siteID, accountID string) error {
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+	if accountID == "" {
+		return fmt.Errorf("accountId is required")
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/radius/accounts/%s", url.PathEscape(siteID), url.PathEscape(accountID))
+	if err := c.do(ctx, http.MethodDelete, urlPath, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete RADIUS account: %w", err)
+	}
+
+	return nil
+}
+
+// withVLANTunnel fills in the tunnel attributes needed for a VLAN assignment
+func withVLANTunnel(account RadiusAccount) RadiusAccount {
+	if account.VLAN > 0 {
+		if account.TunnelType == 0 {
+			account.TunnelType = RadiusTunnelTypeVLAN
+		}
+		if account.TunnelMediumType == 0 {
+			account.TunnelMediumType = RadiusTunnelMediumType802
+		}
+	}
+	return account
+}
@@ -0,0 +1,108 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ListRadiusAccounts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"_id": "acct1", "name": "alice", "vlan": 20, "tunnel_type": 13, "tunnel_medium_type": 6},
+				{"_id": "acct2", "name": "bob"},
+			},
+		})
+
+		accounts, err := client.ListRadiusAccounts(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(accounts) != 2 {
+			t.Fatalf("expected 2 accounts, got %d", len(accounts))
+		}
+		if accounts[0].Name != "alice" || accounts[0].VLAN != 20 || accounts[0].TunnelType != RadiusTunnelTypeVLAN {
+			t.Errorf("unexpected first account: %+v", accounts[0])
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/default/radius/accounts" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{"data": nil})
+
+		accounts, err := client.ListRadiusAccounts(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if accounts == nil || len(accounts) != 0 {
+			t.Errorf("expected empty non-nil slice, got %#v", accounts)
+		}
+	})
+}
+
+func TestClient_CreateRadiusAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("validation failures", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			account *RadiusAccount
+		}{
+			{name: "nil account", account: nil},
+			{name: "missing username", account: &RadiusAccount{Password: "Str0ng-pass"}},
+			{name: "username with space", account: &RadiusAccount{Name: "alice smith", Password: "Str0ng-pass"}},
+			{name: "short password", account: &RadiusAccount{Name: "alice", Password: "Ab1!"}},
+			{name: "weak password", account: &RadiusAccount{Name: "alice", Password: "alllowercase"}},
+			{name: "two character classes", account: &RadiusAccount{Name: "alice", Password: "lowercase123"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client, mock := newTestClient(t, testBaseURL)
+
+				if _, err := client.CreateRadiusAccount(ctx, testSiteID, tt.account); err == nil {
+					t.Fatal("expected validation error, got nil")
+				}
+				if len(mock.requests) != 0 {
+					t.Errorf("expected no requests, got %d", len(mock.requests))
+				}
+			})
+		}
+	})
+
+	t.Run("VLAN fills tunnel attributes", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, map[string]interface{}{
+			"data": []map[string]interface{}{{"_id": "acct1", "name": "alice", "vlan": 30}},
+		})
+
+		account, err := client.CreateRadiusAccount(ctx, testSiteID, &RadiusAccount{
+			Name:     "alice",
+			Password: "Str0ng-pass",
+			VLAN:     30,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if account.ID != "acct1" {
+			t.Errorf("expected account acct1, got %s", account.ID)
+		}
+
+		var sent map[string]interface{}
+		decodeRequestBody(t, mock.request, &sent)
+		if mock.request.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", mock.request.Method)
+		}
+		if sent["x_password"] != "Str0ng-pass" || sent["tunnel_type"] != float64(13) || sent["tunnel_medium_type"] != float64(6) {
+			t.Errorf("unexpected request body: %v", sent)
+		}
+	})
+}
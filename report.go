@@ -0,0 +1,100 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SiteReport summarizes a site's inventory at a point in time, for example
+// for a nightly report email
+type SiteReport struct {
+	Site            Site           `json:"site"`
+	GeneratedAt     time.Time      `json:"generatedAt"`
+	Devices         int            `json:"devices"`
+	DevicesByStatus map[string]int `json:"devicesByStatus"` // Keyed by Device.Status
+	OutdatedDevices int            `json:"outdatedDevices"` // Devices with a firmware upgrade available
+	Clients         int            `json:"clients"`
+	ClientsByType   map[string]int `json:"clientsByType"` // Keyed by NetworkClient.Type (WIRED, WIRELESS, VPN)
+	ActiveVouchers  int            `json:"activeVouchers"`
+}
+
+// GetSiteReport gathers site details, devices, clients and hotspot vouchers
+// concurrently, bounded by WithConcurrency, and summarizes them. Every part is
+// attempted even if another fails; the failures are joined into the returned
+// error alongside a report holding whatever could be gathered.
+func (c *Client) GetSiteReport(ctx context.Context, siteID string) (*SiteReport, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	report := &SiteReport{
+		Site:            Site{ID: siteID},
+		GeneratedAt:     c.clock.Now(),
+		DevicesByStatus: map[string]int{},
+		ClientsByType:   map[string]int{},
+	}
+
+	parts := []func(ctx context.Context) error{
+		func(ctx context.Context) error {
+			site, err := c.GetSite(ctx, siteID)
+			if err != nil {
+				return err
+			}
+			report.Site = *site
+			return nil
+		},
+		func(ctx context.Context) error {
+			devices, err := c.ListAllDevices(ctx, siteID)
+			if err != nil {
+				return fmt.Errorf("failed to list devices: %w", err)
+			}
+			report.Devices = len(devices)
+			for _, device := range devices {
+				report.DevicesByStatus[device.Status()]++
+				if device.NeedsUpgrade() {
+					report.OutdatedDevices++
+				}
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			clients, err := c.ListAllNetworkClients(ctx, siteID)
+			if err != nil {
+				return fmt.Errorf("failed to list clients: %w", err)
+			}
+			report.Clients = len(clients)
+			for _, client := range clients {
+				report.ClientsByType[client.Type]++
+			}
+			return nil
+		},
+		func(ctx context.Context) error {
+			vouchers, err := c.ListAllHotspotVouchers(ctx, siteID)
+			if err != nil {
+				return fmt.Errorf("failed to list hotspot vouchers: %w", err)
+			}
+			for _, voucher := range vouchers {
+				if !voucher.IsExpired(report.GeneratedAt) {
+					report.ActiveVouchers++
+				}
+			}
+			return nil
+		},
+	}
+
+	// Each part writes its own report fields and error slot. Errors are
+	// collected rather than returned to fanOut so one failing part does not
+	// cancel the others.
+	errs := make([]error, len(parts))
+	err := c.fanOut(ctx, len(parts), func(ctx context.Context, i int) error {
+		errs[i] = parts[i](ctx)
+		return nil
+	})
+	if err := errors.Join(append(errs, err)...); err != nil {
+		return report, fmt.Errorf("failed to build site report: %w", err)
+	}
+
+	return report, nil
+}
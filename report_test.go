@@ -0,0 +1,133 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// routeTransport answers each request with the body scripted for its path,
+// and with a 404 for unknown paths. It is safe for concurrent use.
+type routeTransport map[string]string
+
+func (t routeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := t[strings.TrimPrefix(req.URL.Path, "/proxy/network/integration")]
+	if !ok {
+		return mockResponse(404, Error{Status: 404, StatusName: "Not Found", Message: "not found"}), nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}, nil
+}
+
+func siteReportRoutes() routeTransport {
+	return routeTransport{
+		"/v1/sites/default": `{"data":[{"id":"default","name":"Main Office"}]}`,
+		"/v1/sites/default/devices": `{"totalCount":4,"data":[
+			{"_id":"d1","state":1,"upgradable":true,"upgrade_to_firmware":"7.0.1"},
+			{"_id":"d2","state":1},
+			{"_id":"d3","state":0},
+			{"_id":"d4","state":1,"disabled":true}
+		]}`,
+		"/v1/sites/default/clients": `{"totalCount":3,"data":[
+			{"id":"c1","type":"WIRED"},
+			{"id":"c2","type":"WIRELESS"},
+			{"id":"c3","type":"WIRELESS"}
+		]}`,
+		"/v1/sites/default/hotspot/vouchers": `{"totalCount":3,"data":[
+			{"_id":"v1"},
+			{"_id":"v2","expired":true},
+			{"_id":"v3","expiresAt":"2023-12-31T00:00:00Z"}
+		]}`,
+	}
+}
+
+func newReportClient(t *testing.T, routes routeTransport) *Client {
+	t.Helper()
+	client, err := NewClient(testBaseURL,
+		WithAPIKey("test-api-key"),
+		WithHTTPClient(&http.Client{Transport: routes}),
+		WithClock(newFakeClock()),
+		WithConcurrency(2),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestClient_GetSiteReport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("composes report", func(t *testing.T) {
+		client := newReportClient(t, siteReportRoutes())
+
+		report, err := client.GetSiteReport(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := &SiteReport{
+			Site:        Site{ID: "default", Name: "Main Office"},
+			GeneratedAt: newFakeClock().Now(),
+			Devices:     4,
+			DevicesByStatus: map[string]int{
+				DeviceStatusOnline:   2,
+				DeviceStatusOffline:  1,
+				DeviceStatusDisabled: 1,
+			},
+			OutdatedDevices: 1,
+			Clients:         3,
+			ClientsByType:   map[string]int{"WIRED": 1, "WIRELESS": 2},
+			ActiveVouchers:  1,
+		}
+		if !reflect.DeepEqual(report, want) {
+			t.Errorf("report = %+v, want %+v", report, want)
+		}
+	})
+
+	t.Run("joins errors and keeps partial report", func(t *testing.T) {
+		routes := siteReportRoutes()
+		delete(routes, "/v1/sites/default/clients")
+		delete(routes, "/v1/sites/default/hotspot/vouchers")
+		client := newReportClient(t, routes)
+
+		report, err := client.GetSiteReport(ctx, testSiteID)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		for _, part := range []string{"failed to list clients", "failed to list hotspot vouchers"} {
+			if !strings.Contains(err.Error(), part) {
+				t.Errorf("expected error to mention %q, got %v", part, err)
+			}
+		}
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Status != 404 {
+			t.Errorf("expected wrapped 404 API error, got %v", err)
+		}
+
+		if report == nil {
+			t.Fatal("expected partial report, got nil")
+		}
+		if report.Site.Name != "Main Office" || report.Devices != 4 {
+			t.Errorf("expected site and device sections to be filled, got %+v", report)
+		}
+		if report.Clients != 0 || report.ActiveVouchers != 0 {
+			t.Errorf("expected failed sections to be empty, got %+v", report)
+		}
+	})
+
+	t.Run("invalid site", func(t *testing.T) {
+		client := newReportClient(t, siteReportRoutes())
+		if _, err := client.GetSiteReport(ctx, ""); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
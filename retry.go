@@ -0,0 +1,126 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// retryBaseDelay is the delay before the first retry; it doubles on each attempt
+	retryBaseDelay = 500 * time.Millisecond
+	// retryMaxDelay caps the delay between any two attempts
+	retryMaxDelay = 30 * time.Second
+	// reauthDelay is how long to wait before repeating a request that got a 401
+	reauthDelay = 2 * time.Second
+	// idempotencyKeyHeader carries the caller-supplied idempotency key
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// WithMaxRetries retries a request up to n times after a network error or a
+// 429, 502, 503 or 504 response, waiting per the backoff policy or a
+// Retry-After header (capped at 30 seconds); retries are disabled by default.
+// POST and PATCH are only retried when the context carries an idempotency key
+// (see WithIdempotencyKey), since repeating them can duplicate side effects.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithRetryBudget caps the total time spent waiting between retries of a
+// single request. A retry whose delay would push the cumulative wait past
+// total is not attempted and the last error is returned instead. Zero, the
+// default, means no cap beyond WithMaxRetries.
+func WithRetryBudget(total time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryBudget = total
+	}
+}
+
+// WithReauthOn401 makes the client repeat a request once, after a short
+// pause, when the controller answers 401 Unauthorized. API keys do not expire,
+// but a controller that is still starting up after a reboot can reject valid
+// keys for a moment. The repeat applies to every method, since a 401 means the
+// request was not processed, and does not count against WithMaxRetries.
+// Disabled by default.
+func WithReauthOn401(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.reauthOn401 = enabled
+	}
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that sends key in the Idempotency-Key
+// header and allows non-idempotent requests (POST, PATCH) made with it to be
+// retried. Use a unique key per logical operation.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKeyFromContext returns the idempotency key stored in ctx, if any
+func idempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// isRetryableMethod reports whether a request with the given method may be retried
+func isRetryableMethod(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		_, ok := idempotencyKeyFromContext(ctx)
+		return ok
+	}
+}
+
+// shouldRetry reports whether the outcome of an attempt is transient
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP-date, into the time to wait from now. A date in the past
+// yields zero. It reports false for an empty or unparseable header.
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	when, err := http.ParseTime(header)
+	if err != nil {
+		// Some servers send RFC 1123 with a zone other than GMT
+		if when, err = time.Parse(time.RFC1123, header); err != nil {
+			return 0, false
+		}
+	}
+	if wait := when.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
@@ -0,0 +1,496 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient creates a test client with retries enabled on a self-advancing fake clock
+func newRetryTestClient(t *testing.T, maxRetries int) (*Client, *mockTransport, *fakeClock) {
+	t.Helper()
+	client, mock := newTestClient(t, testBaseURL)
+	clock := newFakeClock()
+	clock.autoAdvance = true
+	client.clock = clock
+	client.maxRetries = maxRetries
+	return client, mock, clock
+}
+
+func validGenerateRequest() *GenerateHotspotVouchersRequest {
+	return &GenerateHotspotVouchersRequest{
+		Count:            1,
+		Name:             "Guest",
+		TimeLimitMinutes: 60,
+	}
+}
+
+func TestWithMaxRetries(t *testing.T) {
+	_, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithMaxRetries(-1))
+	if err == nil {
+		t.Fatal("expected error for negative max retries, got nil")
+	}
+}
+
+func TestWithRetryBudget(t *testing.T) {
+	_, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithRetryBudget(-time.Second))
+	if err == nil {
+		t.Fatal("expected error for negative retry budget, got nil")
+	}
+}
+
+func TestClient_do_RetryBudget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries stop once the budget would be exceeded", func(t *testing.T) {
+		client, mock, clock := newRetryTestClient(t, 10)
+		client.retryBudget = 2 * time.Second
+		mock.response = mockRawResponse(503, "<html>maintenance</html>")
+
+		_, err := client.GetApplicationInfo(ctx)
+		if !IsUnavailable(err) {
+			t.Errorf("expected unavailable error, got %v", err)
+		}
+
+		// 500ms + 1s fits in the budget; the next 2s delay would exceed it
+		want := []time.Duration{500 * time.Millisecond, time.Second}
+		if got := clock.Sleeps(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected delays %v, got %v", want, got)
+		}
+		if len(mock.requests) != 3 {
+			t.Errorf("expected 3 requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("delay exactly filling the budget is allowed", func(t *testing.T) {
+		client, mock, clock := newRetryTestClient(t, 10)
+		client.retryBudget = 1500 * time.Millisecond
+		mock.response = mockRawResponse(503, "<html>maintenance</html>")
+
+		_, _ = client.GetApplicationInfo(ctx)
+		if got := len(clock.Sleeps()); got != 2 {
+			t.Errorf("expected 2 retries, got %d", got)
+		}
+	})
+
+	t.Run("network error is returned when budget is spent", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 10)
+		client.retryBudget = 100 * time.Millisecond
+		mock.err = fmt.Errorf("connection refused")
+
+		_, err := client.GetApplicationInfo(ctx)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(mock.requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.requests))
+		}
+	})
+}
+
+func TestClient_do_Retry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GET is retried on 503 with exponential backoff", func(t *testing.T) {
+		client, mock, clock := newRetryTestClient(t, 3)
+		mock.responses = []*http.Response{
+			mockRawResponse(503, "<html>maintenance</html>"),
+			mockRawResponse(503, "<html>maintenance</html>"),
+		}
+		mock.response = mockResponse(200, ApplicationInfo{ApplicationVersion: "9.1.0"})
+
+		info, err := client.GetApplicationInfo(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if info.ApplicationVersion != "9.1.0" {
+			t.Errorf("expected version 9.1.0, got %s", info.ApplicationVersion)
+		}
+		if len(mock.requests) != 3 {
+			t.Errorf("expected 3 requests, got %d", len(mock.requests))
+		}
+
+		want := []time.Duration{500 * time.Millisecond, time.Second}
+		if got := clock.Sleeps(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected backoff %v, got %v", want, got)
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 2)
+		mock.response = mockRawResponse(503, "<html>maintenance</html>")
+
+		_, err := client.GetApplicationInfo(ctx)
+		if !IsUnavailable(err) {
+			t.Errorf("expected unavailable error, got %v", err)
+		}
+		if len(mock.requests) != 3 {
+			t.Errorf("expected 3 requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("network errors are retried", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 1)
+		mock.err = fmt.Errorf("connection reset")
+
+		_, err := client.GetApplicationInfo(ctx)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(mock.requests) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 3)
+		mock.response = mockResponse(404, Error{Status: 404, StatusName: "Not Found", Message: "Site not found"})
+
+		_, err := client.ListSites(ctx, nil)
+		assertErrorResponse(t, err, 404, "Site not found")
+		if len(mock.requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("retries disabled by default", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(503, "<html>maintenance</html>")
+
+		_, _ = client.GetApplicationInfo(ctx)
+		if len(mock.requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("context cancellation stops retries", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		clock := newFakeClock()
+		client.clock = clock
+		client.maxRetries = 5
+		mock.response = mockRawResponse(503, "<html>maintenance</html>")
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.GetApplicationInfo(ctx)
+			done <- err
+		}()
+
+		clock.waitForWaiters(t, 1)
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestClient_GenerateHotspotVouchers_Idempotency(t *testing.T) {
+	ctx := context.Background()
+	success := mockResponse(200, GenerateHotspotVouchersResponse{
+		Data: []HotspotVoucher{{ID: "v1", Code: "12345"}},
+	})
+
+	t.Run("503 without idempotency key is not retried", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 3)
+		mock.responses = []*http.Response{mockRawResponse(503, "<html>maintenance</html>")}
+		mock.response = success
+
+		_, err := client.GenerateHotspotVouchers(ctx, testSiteID, validGenerateRequest())
+		if !IsUnavailable(err) {
+			t.Errorf("expected unavailable error, got %v", err)
+		}
+		if len(mock.requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.requests))
+		}
+		if got := mock.requests[0].Header.Get("Idempotency-Key"); got != "" {
+			t.Errorf("expected no Idempotency-Key header, got %q", got)
+		}
+	})
+
+	t.Run("503 with idempotency key is retried", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 3)
+		mock.responses = []*http.Response{mockRawResponse(503, "<html>maintenance</html>")}
+		mock.response = success
+
+		keyed := WithIdempotencyKey(ctx, "generate-guest-batch-1")
+		resp, err := client.GenerateHotspotVouchers(keyed, testSiteID, validGenerateRequest())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Data) != 1 {
+			t.Errorf("expected 1 voucher, got %d", len(resp.Data))
+		}
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+		}
+		for i, req := range mock.requests {
+			if got := req.Header.Get("Idempotency-Key"); got != "generate-guest-batch-1" {
+				t.Errorf("request %d: expected Idempotency-Key %q, got %q", i, "generate-guest-batch-1", got)
+			}
+		}
+	})
+}
+
+func TestClient_do_RetryReplaysBody(t *testing.T) {
+	ctx := context.Background()
+
+	readBodies := func(t *testing.T, requests []*http.Request) [][]byte {
+		t.Helper()
+		bodies := make([][]byte, len(requests))
+		for i, req := range requests {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("request %d: failed to read body: %v", i, err)
+			}
+			bodies[i] = body
+		}
+		return bodies
+	}
+
+	t.Run("POST retried after 503", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 3)
+		mock.responses = []*http.Response{mockRawResponse(503, "<html>maintenance</html>")}
+		mock.response = mockResponse(200, GenerateHotspotVouchersResponse{})
+
+		keyed := WithIdempotencyKey(ctx, "replay-1")
+		if _, err := client.GenerateHotspotVouchers(keyed, testSiteID, validGenerateRequest()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+		}
+
+		bodies := readBodies(t, mock.requests)
+		if len(bodies[0]) == 0 {
+			t.Fatal("expected a request body on the first attempt")
+		}
+		if !bytes.Equal(bodies[0], bodies[1]) {
+			t.Errorf("expected identical bodies, got %q and %q", bodies[0], bodies[1])
+		}
+		for i, req := range mock.requests {
+			if req.ContentLength != int64(len(bodies[i])) {
+				t.Errorf("request %d: expected content length %d, got %d", i, len(bodies[i]), req.ContentLength)
+			}
+		}
+	})
+
+	t.Run("PUT retried after 503", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 3)
+		mock.responses = []*http.Response{
+			mockRawResponse(503, "<html>maintenance</html>"),
+			mockRawResponse(503, "<html>maintenance</html>"),
+		}
+		mock.response = mockResponse(200, GuestControl{})
+
+		settings := GuestControl{PortalEnabled: true, AuthType: GuestAuthPassword, ExpireMinutes: 480}
+		if _, err := client.UpdateGuestControl(ctx, testSiteID, settings); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.requests) != 3 {
+			t.Fatalf("expected 3 requests, got %d", len(mock.requests))
+		}
+
+		bodies := readBodies(t, mock.requests)
+		for i := 1; i < len(bodies); i++ {
+			if !bytes.Equal(bodies[0], bodies[i]) {
+				t.Errorf("attempt %d: expected body %q, got %q", i+1, bodies[0], bodies[i])
+			}
+		}
+	})
+}
+
+func TestDefaultBackoff(t *testing.T) {
+	want := []time.Duration{
+		500 * time.Millisecond,
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		30 * time.Second,
+		30 * time.Second,
+	}
+	for attempt, delay := range want {
+		if got := defaultBackoff.NextDelay(attempt); got != delay {
+			t.Errorf("defaultBackoff.NextDelay(%d) = %v, want %v", attempt, got, delay)
+		}
+	}
+}
+
+func TestWithReauthOn401(t *testing.T) {
+	ctx := context.Background()
+	unauthorized := func() *http.Response {
+		return mockResponse(401, Error{Status: 401, StatusName: "UNAUTHORIZED", Message: "Unauthorized"})
+	}
+
+	t.Run("transient 401 is repeated once", func(t *testing.T) {
+		client, mock, clock := newRetryTestClient(t, 0)
+		client.reauthOn401 = true
+		mock.responses = []*http.Response{unauthorized()}
+		mock.response = mockResponse(200, ApplicationInfo{ApplicationVersion: "9.1.0"})
+
+		info, err := client.GetApplicationInfo(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if info.ApplicationVersion != "9.1.0" {
+			t.Errorf("expected version 9.1.0, got %q", info.ApplicationVersion)
+		}
+		if len(mock.requests) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(mock.requests))
+		}
+		if got, want := clock.Sleeps(), []time.Duration{reauthDelay}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected sleeps %v, got %v", want, got)
+		}
+	})
+
+	t.Run("POST is repeated with the same body", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 0)
+		client.reauthOn401 = true
+		mock.responses = []*http.Response{unauthorized()}
+		mock.response = mockResponse(200, GenerateHotspotVouchersResponse{})
+
+		if _, err := client.GenerateHotspotVouchers(ctx, testSiteID, validGenerateRequest()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.requests) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(mock.requests))
+		}
+		var got GenerateHotspotVouchersRequest
+		decodeRequestBody(t, mock.requests[1], &got)
+		if got != *validGenerateRequest() {
+			t.Errorf("expected repeated body %+v, got %+v", *validGenerateRequest(), got)
+		}
+	})
+
+	t.Run("persistent 401 is returned after one repeat", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 3)
+		client.reauthOn401 = true
+		mock.responses = []*http.Response{unauthorized()}
+		mock.response = unauthorized()
+
+		_, err := client.GetApplicationInfo(ctx)
+		assertErrorResponse(t, err, 401, "Unauthorized")
+		if len(mock.requests) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("repeat does not use up retries", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 1)
+		client.reauthOn401 = true
+		mock.responses = []*http.Response{unauthorized(), mockRawResponse(503, "<html>maintenance</html>")}
+		mock.response = mockResponse(200, ApplicationInfo{ApplicationVersion: "9.1.0"})
+
+		if _, err := client.GetApplicationInfo(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.requests) != 3 {
+			t.Errorf("expected 3 requests, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		client, mock, _ := newRetryTestClient(t, 3)
+		mock.responses = []*http.Response{unauthorized()}
+		mock.response = mockResponse(200, ApplicationInfo{})
+
+		_, err := client.GetApplicationInfo(ctx)
+		assertErrorResponse(t, err, 401, "Unauthorized")
+		if len(mock.requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(mock.requests))
+		}
+	})
+
+	t.Run("option enables repeat", func(t *testing.T) {
+		client, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithReauthOn401(true))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !client.reauthOn401 {
+			t.Error("expected reauthOn401 to be set")
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"seconds", "120", 2 * time.Minute, true},
+		{"seconds with spaces", " 5 ", 5 * time.Second, true},
+		{"zero seconds", "0", 0, true},
+		{"negative seconds", "-10", 0, true},
+		{"future date", now.Add(90 * time.Second).Format(time.RFC1123), 90 * time.Second, true},
+		{"future date in GMT", "Mon, 01 Jan 2024 12:01:00 GMT", time.Minute, true},
+		{"past date", now.Add(-time.Hour).Format(http.TimeFormat), 0, true},
+		{"empty", "", 0, false},
+		{"garbage", "soon", 0, false},
+		{"fractional seconds", "1.5", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.header, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestClient_do_RetryAfter(t *testing.T) {
+	ctx := context.Background()
+
+	withRetryAfter := func(value string) *http.Response {
+		resp := mockResponse(429, Error{Status: 429, Message: "Too Many Requests"})
+		resp.Header = http.Header{"Retry-After": {value}}
+		return resp
+	}
+
+	tests := []struct {
+		name      string
+		header    string
+		wantSleep time.Duration
+	}{
+		{"seconds replace backoff", "3", 3 * time.Second},
+		{"zero retries immediately", "0", 0},
+		{"long waits are capped", "3600", retryMaxDelay},
+		{"garbage falls back to backoff", "later", 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, clock := newRetryTestClient(t, 1)
+			mock.responses = []*http.Response{withRetryAfter(tt.header)}
+			mock.response = mockResponse(200, ApplicationInfo{})
+
+			if _, err := client.GetApplicationInfo(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(mock.requests) != 2 {
+				t.Errorf("expected 2 requests, got %d", len(mock.requests))
+			}
+
+			var want []time.Duration
+			if tt.wantSleep > 0 {
+				want = []time.Duration{tt.wantSleep}
+			}
+			if got := clock.Sleeps(); !reflect.DeepEqual(got, want) {
+				t.Errorf("expected sleeps %v, got %v", want, got)
+			}
+		})
+	}
+}
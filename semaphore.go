@@ -0,0 +1,36 @@
+package unifi
+
+import "context"
+
+// WithMaxConcurrentRequests bounds how many requests the client has in flight
+// at once, across all goroutines. Requests beyond the limit wait for a free
+// slot or for their context to be done. Unlike WithConcurrency, which only
+// sizes fan-out helpers, this applies to every request. Zero, the default,
+// means no limit.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		c.maxInFlight = n
+	}
+}
+
+// acquire takes an in-flight request slot, waiting until one is free or ctx
+// is done. It is a no-op when no limit is configured.
+func (c *Client) acquire(ctx context.Context) error {
+	if c.inFlight == nil {
+		return nil
+	}
+
+	select {
+	case c.inFlight <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release returns a slot taken by acquire
+func (c *Client) release() {
+	if c.inFlight != nil {
+		<-c.inFlight
+	}
+}
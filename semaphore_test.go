@@ -0,0 +1,71 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	t.Run("negative value", func(t *testing.T) {
+		_, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithMaxConcurrentRequests(-1))
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("bounds in-flight requests", func(t *testing.T) {
+		const limit = 3
+		transport := &countingTransport{delay: 10 * time.Millisecond}
+		client := newCountingClient(t, transport, WithMaxConcurrentRequests(limit))
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 20)
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if _, err := client.GetDevice(context.Background(), testSiteID, deviceIDs(20)[i]); err != nil {
+					errs <- err
+				}
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if peak := transport.maxInFlight.Load(); peak > limit {
+			t.Errorf("expected at most %d requests in flight, got %d", limit, peak)
+		}
+	})
+
+	t.Run("waiting respects context", func(t *testing.T) {
+		transport := &countingTransport{delay: time.Second}
+		client := newCountingClient(t, transport, WithMaxConcurrentRequests(1))
+
+		// Occupy the only slot
+		holdCtx, release := context.WithCancel(context.Background())
+		defer release()
+		go func() {
+			_, _ = client.GetDevice(holdCtx, testSiteID, "device-0")
+		}()
+		for transport.inFlight.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := client.GetDevice(ctx, testSiteID, "device-1")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if got := transport.maxInFlight.Load(); got != 1 {
+			t.Errorf("expected the waiting request never to be sent, peak in-flight %d", got)
+		}
+	})
+}
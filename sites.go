@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"unicode"
 )
 
 // Site represents a UniFi site
@@ -13,42 +15,62 @@ type Site struct {
 	Name string `json:"name"` // Site name
 }
 
+// validateSiteID rejects site IDs that cannot be a valid path segment, so
+// callers get a clear error instead of a confusing 404 from the controller
+func validateSiteID(siteID string) error {
+	if siteID == "" {
+		return fmt.Errorf("siteId is required")
+	}
+	if strings.Contains(siteID, "/") {
+		return fmt.Errorf("invalid siteId %q: must not contain '/'", siteID)
+	}
+	if strings.IndexFunc(siteID, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("invalid siteId %q: must not contain whitespace", siteID)
+	}
+	return nil
+}
+
 // ListSitesParams contains parameters for listing sites
 type ListSitesParams struct {
-	Offset int `json:"offset,omitempty"` // Default: 0
-	Limit  int `json:"limit,omitempty"`  // [0..200] Default: 25
+	Offset int    `json:"offset,omitempty"` // Default: 0
+	Limit  int    `json:"limit,omitempty"`  // [0..200] or LimitMax, Default: 25
+	Cursor string `json:"cursor,omitempty"` // Next page token from a cursor-paginated response
 }
 
 // ListSitesResponse represents the response from listing sites
 type ListSitesResponse struct {
-	Offset     int    `json:"offset"`     // Starting offset
-	Limit      int    `json:"limit"`      // Number of sites per page
-	Count      int    `json:"count"`      // Number of sites in this response
-	TotalCount int    `json:"totalCount"` // Total number of sites available
-	Data       []Site `json:"data"`       // List of sites
+	Offset     int    `json:"offset"`               // Starting offset
+	Limit      int    `json:"limit"`                // Number of sites per page
+	Count      int    `json:"count"`                // Number of sites in this response
+	TotalCount int    `json:"totalCount"`           // Total number of sites available
+	Data       []Site `json:"data"`                 // List of sites
+	NextCursor string `json:"nextCursor,omitempty"` // Next page token on cursor-paginated controllers
+	Cursor     string `json:"cursor,omitempty"`     // Alternative name some controllers use for NextCursor
+}
+
+// NextPageCursor returns the token for the next page, see PaginatedResponse.NextPageCursor
+func (r *ListSitesResponse) NextPageCursor() string {
+	return firstCursor(r.NextCursor, r.Cursor)
 }
 
 // ListSites retrieves all sites accessible to the authenticated user
 // If Multi-Site option is enabled, returns all created sites.
 // If Multi-Site option is disabled, returns just the default site.
 func (c *Client) ListSites(ctx context.Context, params *ListSitesParams) (*ListSitesResponse, error) {
-	const maxLimit = 200
+	query, err := buildQuery(params, c.maxPageLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listSites(ctx, query)
+}
+
+// listSites fetches one page of sites using pre-built query parameters
+func (c *Client) listSites(ctx context.Context, query url.Values) (*ListSitesResponse, error) {
 	urlPath := "/v1/sites"
 
-	if params != nil {
-		query := url.Values{}
-		if params.Offset > 0 {
-			query.Set("offset", fmt.Sprint(params.Offset))
-		}
-		if params.Limit > 0 {
-			if params.Limit > maxLimit {
-				return nil, fmt.Errorf("limit must be between 0 and %d", maxLimit)
-			}
-			query.Set("limit", fmt.Sprint(params.Limit))
-		}
-		if len(query) > 0 {
-			urlPath += "?" + query.Encode()
-		}
+	if len(query) > 0 {
+		urlPath += "?" + query.Encode()
 	}
 
 	var response ListSitesResponse
@@ -58,3 +80,27 @@ func (c *Client) ListSites(ctx context.Context, params *ListSitesParams) (*ListS
 
 	return &response, nil
 }
+
+// ListAllSites retrieves every site accessible to the authenticated user, following offset or cursor pagination
+func (c *Client) ListAllSites(ctx context.Context) ([]Site, error) {
+	return collect(c.IterSites(ctx, nil))
+}
+
+// GetSite retrieves a specific site by ID
+func (c *Client) GetSite(ctx context.Context, siteID string) (*Site, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s", url.PathEscape(siteID))
+	site, _, err := getFirst[Site](ctx, c, urlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get site: %w", err)
+	}
+
+	if site == nil {
+		return nil, &NotFoundError{Resource: "site", ID: siteID}
+	}
+
+	return site, nil
+}
@@ -2,6 +2,7 @@ package unifi
 
 import (
 	"context"
+	"strings"
 	"testing"
 )
 
@@ -121,3 +122,163 @@ func TestClient_ListSites(t *testing.T) {
 		assertErrorResponse(t, err, 401, "Invalid credentials")
 	})
 }
+
+func TestClient_ListSites_LimitMax(t *testing.T) {
+	client, mock := newTestClient(t, testBaseURL)
+	mock.response = mockResponse(200, ListSitesResponse{})
+
+	if _, err := client.ListSites(context.Background(), &ListSitesParams{Limit: LimitMax}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := mock.request.URL.Query().Get("limit"); got != "200" {
+		t.Errorf("expected limit=200, got %q", got)
+	}
+}
+
+func TestClient_GetSite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, struct {
+			Data []Site `json:"data"`
+		}{
+			Data: []Site{{ID: "default", Name: "Default"}},
+		})
+
+		result, err := client.GetSite(ctx, "default")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.ID != "default" {
+			t.Errorf("expected site ID default, got %s", result.ID)
+		}
+		if result.Name != "Default" {
+			t.Errorf("expected site name Default, got %s", result.Name)
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/default" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+	})
+
+	t.Run("site not found", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(200, struct {
+			Data []Site `json:"data"`
+		}{
+			Data: []Site{},
+		})
+
+		_, err := client.GetSite(ctx, "nonexistent")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "site not found: nonexistent") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("API not found error", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+
+		mock.response = mockResponse(404, Error{
+			Status:     404,
+			StatusName: "Not Found",
+			Message:    "Site not found",
+		})
+
+		_, err := client.GetSite(ctx, "nonexistent")
+		assertErrorResponse(t, err, 404, "Site not found")
+	})
+
+	t.Run("empty site ID", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		_, err := client.GetSite(ctx, "")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestValidateSiteID(t *testing.T) {
+	tests := []struct {
+		name    string
+		siteID  string
+		wantErr string
+	}{
+		{name: "valid", siteID: "default"},
+		{name: "valid uuid", siteID: "88f7af54-98f8-306a-a1c7-c9349722b1f6"},
+		{name: "empty", siteID: "", wantErr: "siteId is required"},
+		{name: "contains slash", siteID: "default/devices", wantErr: "must not contain '/'"},
+		{name: "contains space", siteID: "my site", wantErr: "must not contain whitespace"},
+		{name: "trailing newline", siteID: "default\n", wantErr: "must not contain whitespace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSiteID(tt.siteID)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestClient_SiteScopedMethods_RejectInvalidSiteID(t *testing.T) {
+	ctx := context.Background()
+
+	for _, siteID := range []string{"default/../other", "default site"} {
+		t.Run(siteID, func(t *testing.T) {
+			client, mock := newTestClient(t, testBaseURL)
+
+			calls := map[string]func() error{
+				"ListDevices": func() error {
+					_, err := client.ListDevices(ctx, siteID, nil)
+					return err
+				},
+				"GetDevice": func() error {
+					_, err := client.GetDevice(ctx, siteID, "device1")
+					return err
+				},
+				"ListNetworkClients": func() error {
+					_, err := client.ListNetworkClients(ctx, siteID, nil)
+					return err
+				},
+				"GetHotspotVoucher": func() error {
+					_, err := client.GetHotspotVoucher(ctx, siteID, "voucher1")
+					return err
+				},
+				"DeleteHotspotVoucher": func() error {
+					return client.DeleteHotspotVoucher(ctx, siteID, "voucher1")
+				},
+				"GetSite": func() error {
+					_, err := client.GetSite(ctx, siteID)
+					return err
+				},
+			}
+
+			for name, call := range calls {
+				if err := call(); err == nil || !strings.Contains(err.Error(), "invalid siteId") {
+					t.Errorf("%s: expected invalid siteId error, got %v", name, err)
+				}
+			}
+			if len(mock.requests) != 0 {
+				t.Errorf("expected no requests to be sent, got %d", len(mock.requests))
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SiteSnapshot is a point-in-time capture of a site's devices, clients and
+// hotspot vouchers, suitable for replaying in tests (see the unifitest package)
+type SiteSnapshot struct {
+	SiteID   string           `json:"siteId"`
+	Devices  []Device         `json:"devices"`
+	Clients  []NetworkClient  `json:"clients"`
+	Vouchers []HotspotVoucher `json:"vouchers"`
+}
+
+// SnapshotSite captures every device, client and hotspot voucher on a site
+// and writes them to w as a single indented JSON document
+func (c *Client) SnapshotSite(ctx context.Context, siteID string, w io.Writer) error {
+	devices, err := c.ListAllDevices(ctx, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot site: %w", err)
+	}
+
+	clients, err := c.ListAllNetworkClients(ctx, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot site: %w", err)
+	}
+
+	vouchers, err := c.ListAllHotspotVouchers(ctx, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot site: %w", err)
+	}
+
+	snapshot := SiteSnapshot{
+		SiteID:   siteID,
+		Devices:  nonNil(devices),
+		Clients:  nonNil(clients),
+		Vouchers: nonNil(vouchers),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSiteSnapshot reads a snapshot written by SnapshotSite
+func LoadSiteSnapshot(r io.Reader) (*SiteSnapshot, error) {
+	var snapshot SiteSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	if err := validateSiteID(snapshot.SiteID); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// nonNil returns s, or an empty slice if s is nil, so it encodes as [] rather than null
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
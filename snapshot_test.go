@@ -0,0 +1,78 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_SnapshotSite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("empty site encodes empty lists", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.responses = []*http.Response{
+			mockResponse(200, ListDevicesResponse{}),
+			mockResponse(200, ListNetworkClientsResponse{}),
+			mockResponse(200, ListHotspotVouchersResponse{}),
+		}
+
+		var buf bytes.Buffer
+		if err := client.SnapshotSite(ctx, testSiteID, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("invalid snapshot JSON: %v", err)
+		}
+		for _, key := range []string{"devices", "clients", "vouchers"} {
+			if got := string(doc[key]); got != "[]" {
+				t.Errorf("expected %s to be [], got %s", key, got)
+			}
+		}
+		if got := string(doc["siteId"]); got != `"default"` {
+			t.Errorf("expected siteId default, got %s", got)
+		}
+	})
+
+	t.Run("list error is returned", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(500, map[string]string{"message": "boom"})
+
+		var buf bytes.Buffer
+		if err := client.SnapshotSite(ctx, testSiteID, &buf); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if buf.Len() != 0 {
+			t.Errorf("expected nothing written on error, got %q", buf.String())
+		}
+	})
+}
+
+func TestLoadSiteSnapshot(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		snapshot, err := LoadSiteSnapshot(strings.NewReader(`{"siteId":"default","devices":[{"_id":"ap1"}]}`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if snapshot.SiteID != "default" || len(snapshot.Devices) != 1 || snapshot.Devices[0].ID != "ap1" {
+			t.Errorf("unexpected snapshot: %+v", snapshot)
+		}
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		if _, err := LoadSiteSnapshot(strings.NewReader(`{"siteId":`)); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("missing site ID", func(t *testing.T) {
+		if _, err := LoadSiteSnapshot(strings.NewReader(`{"devices":[]}`)); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// speedTestPollInterval is the initial delay between speed test status checks
+	speedTestPollInterval = 2 * time.Second
+	// speedTestMaxPollInterval caps the delay between speed test status checks
+	speedTestMaxPollInterval = 10 * time.Second
+)
+
+// SpeedTestResult represents the result of a gateway speed test
+type SpeedTestResult struct {
+	DownloadMbps float64 `json:"xput_download"` // Download throughput in Mbps
+	UploadMbps   float64 `json:"xput_upload"`   // Upload throughput in Mbps
+	LatencyMs    float64 `json:"latency"`       // Latency in milliseconds
+	RunDate      int64   `json:"rundate"`       // When the test ran, in seconds since epoch
+	Server       string  `json:"server"`        // Test server, if reported
+}
+
+// RunTime returns when the speed test ran
+func (r SpeedTestResult) RunTime() time.Time {
+	return time.Unix(r.RunDate, 0)
+}
+
+// SpeedTestCommand represents a command sent to the gateway speed test
+type SpeedTestCommand struct {
+	Cmd string `json:"cmd"` // Command to perform (speedtest)
+}
+
+// GetLastSpeedTest retrieves the most recent speed test result for a site
+func (c *Client) GetLastSpeedTest(ctx context.Context, siteID string) (*SpeedTestResult, error) {
+	result, err := c.lastSpeedTest(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get speed test result: %w", err)
+	}
+
+	if result == nil {
+		return nil, &NotFoundError{Resource: "speed test result", ID: siteID}
+	}
+
+	return result, nil
+}
+
+// lastSpeedTest fetches the most recent speed test result, or nil if there is none
+func (c *Client) lastSpeedTest(ctx context.Context, siteID string) (*SpeedTestResult, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/speedtest", url.PathEscape(siteID))
+	result, _, err := getFirst[SpeedTestResult](ctx, c, urlPath)
+	return result, err
+}
+
+// RunSpeedTest triggers a gateway speed test and polls until its result is
+// available. Use a context deadline to bound how long to wait.
+func (c *Client) RunSpeedTest(ctx context.Context, siteID string) (*SpeedTestResult, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	// rundate has second resolution, so compare against the start of this second
+	started := c.clock.Now().Truncate(time.Second)
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/speedtest", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodPost, urlPath, &SpeedTestCommand{Cmd: "speedtest"}, nil); err != nil {
+		return nil, fmt.Errorf("failed to start speed test: %w", err)
+	}
+
+	var result *SpeedTestResult
+	err := c.pollWithBackoff(ctx, speedTestPollInterval, speedTestMaxPollInterval, func() (bool, error) {
+		latest, err := c.lastSpeedTest(ctx, siteID)
+		if err != nil {
+			return false, err
+		}
+		if latest == nil || latest.RunTime().Before(started) {
+			return false, nil
+		}
+		result = latest
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get speed test result: %w", err)
+	}
+
+	return result, nil
+}
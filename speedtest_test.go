@@ -0,0 +1,127 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func speedTestResponse(results ...SpeedTestResult) *http.Response {
+	return mockResponse(200, struct {
+		Data []SpeedTestResult `json:"data"`
+	}{Data: results})
+}
+
+func TestClient_RunSpeedTest(t *testing.T) {
+	ctx := context.Background()
+	// newFakeClock starts at 2024-01-01T00:00:00Z
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("trigger then poll to result", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		clock := newFakeClock()
+		clock.autoAdvance = true
+		client.clock = clock
+
+		stale := SpeedTestResult{DownloadMbps: 100, RunDate: start.Add(-time.Hour).Unix()}
+		fresh := SpeedTestResult{DownloadMbps: 940.5, UploadMbps: 38.2, LatencyMs: 9, RunDate: start.Add(5 * time.Second).Unix()}
+		mock.responses = []*http.Response{
+			mockResponse(200, nil),
+			speedTestResponse(stale),
+			speedTestResponse(),
+			speedTestResponse(fresh),
+		}
+
+		result, err := client.RunSpeedTest(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if *result != fresh {
+			t.Errorf("expected result %+v, got %+v", fresh, *result)
+		}
+
+		if len(mock.requests) != 4 {
+			t.Fatalf("expected 4 requests, got %d", len(mock.requests))
+		}
+		trigger := mock.requests[0]
+		if trigger.Method != http.MethodPost {
+			t.Errorf("expected trigger method POST, got %s", trigger.Method)
+		}
+		var cmd SpeedTestCommand
+		decodeRequestBody(t, trigger, &cmd)
+		if cmd.Cmd != "speedtest" {
+			t.Errorf("expected cmd %q, got %q", "speedtest", cmd.Cmd)
+		}
+		for _, req := range mock.requests[1:] {
+			if req.Method != http.MethodGet {
+				t.Errorf("expected poll method GET, got %s", req.Method)
+			}
+		}
+
+		want := []time.Duration{2 * time.Second, 4 * time.Second}
+		if got := clock.Sleeps(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected poll delays %v, got %v", want, got)
+		}
+	})
+
+	t.Run("trigger failure", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(400, Error{Status: 400, StatusName: "Bad Request", Message: "No gateway"})
+
+		_, err := client.RunSpeedTest(ctx, testSiteID)
+		assertErrorResponse(t, err, 400, "No gateway")
+		if len(mock.requests) != 1 {
+			t.Errorf("expected no polling after a failed trigger, got %d requests", len(mock.requests))
+		}
+	})
+
+	t.Run("context timeout while polling", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		client.clock = newFakeClock()
+		mock.responses = []*http.Response{mockResponse(200, nil)}
+		mock.response = speedTestResponse()
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := client.RunSpeedTest(ctx, testSiteID)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+func TestClient_GetLastSpeedTest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":[{"xput_download":512.3,"xput_upload":20.1,"latency":12,"rundate":1700000000}]}`)
+
+		result, err := client.GetLastSpeedTest(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.DownloadMbps != 512.3 || result.UploadMbps != 20.1 || result.LatencyMs != 12 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if !result.RunTime().Equal(time.Unix(1700000000, 0)) {
+			t.Errorf("unexpected run time: %v", result.RunTime())
+		}
+	})
+
+	t.Run("no result", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = speedTestResponse()
+
+		_, err := client.GetLastSpeedTest(ctx, testSiteID)
+		if !IsNotFound(err) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
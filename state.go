@@ -0,0 +1,114 @@
+package unifi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// clientState holds everything on a Client that can change after NewClient
+// returns. Every other Client field is configuration that is only written by
+// options before the client is shared, so it needs no locking. The list cache
+// and device model cache guard themselves, and the in-flight limit is a
+// channel.
+//
+// All reads and writes of clientState fields after construction go through
+// mu. Options may write the fields directly because they only ever run on a
+// client (or clone) no other goroutine can see yet.
+type clientState struct {
+	mu            sync.RWMutex
+	apiKey        string
+	lastRateLimit *RateLimit // Nil until a response carries rate limit headers
+}
+
+// copy returns an independent copy of the state for a cloned client
+func (s *clientState) copy() *clientState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &clientState{apiKey: s.apiKey, lastRateLimit: s.lastRateLimit}
+}
+
+// key returns the API key to send with the next request
+func (s *clientState) key() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.apiKey
+}
+
+// RateLimit is the controller's request quota as reported on the most
+// recent response
+type RateLimit struct {
+	Limit     int       // Requests allowed in the current window
+	Remaining int       // Requests left in the current window
+	Reset     time.Time // When the window resets, zero if not reported
+}
+
+// Rate limit response headers
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// parseRateLimit reads the X-RateLimit-* headers. Reset is given in Unix
+// seconds. It returns false when the limit or remaining count is missing or
+// malformed.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get(rateLimitLimitHeader))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get(rateLimitRemainingHeader))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rl := RateLimit{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.ParseInt(header.Get(rateLimitResetHeader), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl, true
+}
+
+// recordRateLimit stores the rate limit reported by resp, if any
+func (c *Client) recordRateLimit(resp *http.Response) {
+	rl, ok := parseRateLimit(resp.Header)
+	if !ok {
+		return
+	}
+
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	c.state.lastRateLimit = &rl
+}
+
+// LastRateLimit returns the rate limit reported on the most recent response
+// that carried X-RateLimit-* headers. It returns false if no response has
+// reported one yet. It is safe to call while requests are in flight.
+func (c *Client) LastRateLimit() (RateLimit, bool) {
+	c.state.mu.RLock()
+	defer c.state.mu.RUnlock()
+	if c.state.lastRateLimit == nil {
+		return RateLimit{}, false
+	}
+	return *c.state.lastRateLimit, true
+}
+
+// SetAPIKey replaces the API key used for subsequent requests, for example
+// after the key is rotated. Requests already in flight keep the key they
+// were sent with. Cached list responses are dropped, since they were fetched
+// with the old key. It is safe to call while requests are in flight.
+func (c *Client) SetAPIKey(apiKey string) error {
+	if apiKey == "" {
+		return fmt.Errorf("API key is required")
+	}
+
+	c.state.mu.Lock()
+	c.state.apiKey = apiKey
+	c.state.mu.Unlock()
+
+	c.listCache.clear()
+	return nil
+}
@@ -0,0 +1,248 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   RateLimit
+		wantOK bool
+	}{
+		{
+			name: "all headers",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"100"},
+				"X-Ratelimit-Remaining": {"42"},
+				"X-Ratelimit-Reset":     {"1700000000"},
+			},
+			want:   RateLimit{Limit: 100, Remaining: 42, Reset: time.Unix(1700000000, 0)},
+			wantOK: true,
+		},
+		{
+			name:   "no reset",
+			header: http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"0"}},
+			want:   RateLimit{Limit: 100},
+			wantOK: true,
+		},
+		{name: "no headers", header: http.Header{}},
+		{name: "missing remaining", header: http.Header{"X-Ratelimit-Limit": {"100"}}},
+		{name: "malformed limit", header: http.Header{"X-Ratelimit-Limit": {"lots"}, "X-Ratelimit-Remaining": {"1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRateLimit(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%t, got %t", tt.wantOK, ok)
+			}
+			if !got.Reset.Equal(tt.want.Reset) || got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClient_LastRateLimit(t *testing.T) {
+	ctx := context.Background()
+	client, mock := newTestClient(t, testBaseURL)
+
+	if _, ok := client.LastRateLimit(); ok {
+		t.Fatal("expected no rate limit before any request")
+	}
+
+	limited := mockResponse(200, ListDevicesResponse{})
+	limited.Header = http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"99"}}
+	mock.responses = []*http.Response{limited, mockResponse(200, ListDevicesResponse{})}
+
+	if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rl, ok := client.LastRateLimit(); !ok || rl.Limit != 100 || rl.Remaining != 99 {
+		t.Errorf("expected limit 100/99, got %+v (ok=%t)", rl, ok)
+	}
+
+	// A response without rate limit headers keeps the last reported limit
+	if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rl, ok := client.LastRateLimit(); !ok || rl.Remaining != 99 {
+		t.Errorf("expected remaining 99 to be kept, got %+v (ok=%t)", rl, ok)
+	}
+}
+
+func TestClient_SetAPIKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("used for subsequent requests", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, ListDevicesResponse{})
+
+		if err := client.SetAPIKey("rotated-key"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := mock.request.Header.Get("X-API-KEY"); got != "rotated-key" {
+			t.Errorf("expected X-API-KEY rotated-key, got %q", got)
+		}
+	})
+
+	t.Run("drops cached lists", func(t *testing.T) {
+		client, mock, _ := newCachingTestClient(t, time.Minute)
+		mock.responses = []*http.Response{devicesResponse("ap1"), devicesResponse("ap2")}
+
+		if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := client.SetAPIKey("rotated-key"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, err := client.ListDevices(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.requests) != 2 || resp.Data[0].ID != "ap2" {
+			t.Errorf("expected a fresh fetch after rotation, got %d requests and %+v", len(mock.requests), resp.Data)
+		}
+		if got := mock.request.Header.Get("X-API-KEY"); got != "rotated-key" {
+			t.Errorf("expected X-API-KEY rotated-key, got %q", got)
+		}
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if err := client.SetAPIKey(""); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if got := client.state.key(); got != "test-api-key" {
+			t.Errorf("expected key to be unchanged, got %q", got)
+		}
+	})
+
+	t.Run("clones are independent", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+		clone, err := client.Clone()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := clone.SetAPIKey("clone-key"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := client.state.key(); got != "test-api-key" {
+			t.Errorf("expected original key to be unchanged, got %q", got)
+		}
+	})
+}
+
+// concurrentTransport is a RoundTripper that is safe for concurrent use. It
+// answers every request with an empty list and rate limit headers.
+type concurrentTransport struct {
+	calls atomic.Int64
+	mu    sync.Mutex
+	keys  map[string]bool // Every X-API-KEY seen
+}
+
+func (t *concurrentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := t.calls.Add(1)
+	t.mu.Lock()
+	t.keys[req.Header.Get("X-API-KEY")] = true
+	t.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ratelimit-Limit":     {"1000"},
+			"X-Ratelimit-Remaining": {strconv.FormatInt(1000-n, 10)},
+		},
+		Body: io.NopCloser(bytes.NewReader([]byte(`{"data":[]}`))),
+	}, nil
+}
+
+// TestClient_ConcurrentState exercises the client's shared state from many
+// goroutines at once. It asserts nothing beyond consistency on its own; run
+// it with -race to detect unsynchronized access.
+func TestClient_ConcurrentState(t *testing.T) {
+	ctx := context.Background()
+	transport := &concurrentTransport{keys: make(map[string]bool)}
+	client, err := NewClient(testBaseURL,
+		WithAPIKey("key-0"),
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithListCache(time.Minute),
+		WithMaxConcurrentRequests(4),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	const workers = 8
+	const iterations = 25
+	valid := map[string]bool{"key-0": true}
+	for i := 1; i <= iterations; i++ {
+		valid[fmt.Sprintf("key-%d", i)] = true
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				// Alternate reads that populate the cache with writes that
+				// invalidate it
+				if (w+i)%2 == 0 {
+					if _, err := client.ListDevices(ctx, testSiteID, nil); err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+				} else if err := client.DeleteHotspotVoucher(ctx, testSiteID, "voucher"); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}(w)
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < iterations*workers; i++ {
+			if rl, ok := client.LastRateLimit(); ok && rl.Limit != 1000 {
+				t.Errorf("expected limit 1000, got %d", rl.Limit)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= iterations; i++ {
+			if err := client.SetAPIKey(fmt.Sprintf("key-%d", i)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+	}()
+	wg.Wait()
+
+	if transport.calls.Load() == 0 {
+		t.Fatal("expected requests to reach the transport")
+	}
+	for key := range transport.keys {
+		if !valid[key] {
+			t.Errorf("unexpected API key sent: %q", key)
+		}
+	}
+	if _, ok := client.LastRateLimit(); !ok {
+		t.Error("expected a rate limit to be recorded")
+	}
+}
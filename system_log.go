@@ -0,0 +1,95 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// System log levels
+const (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+var knownLogLevels = map[string]bool{
+	LogLevelDebug: true,
+	LogLevelInfo:  true,
+	LogLevelWarn:  true,
+	LogLevelError: true,
+}
+
+// LogEntry represents a single line of the controller's system log
+type LogEntry struct {
+	ID        string `json:"_id"`       // Unique identifier
+	Time      int64  `json:"time"`      // Entry timestamp in milliseconds since epoch
+	Level     string `json:"level"`     // Log level (debug, info, warn, error)
+	Subsystem string `json:"subsystem"` // Component that logged the entry (e.g., wlan, lan, system)
+	Message   string `json:"msg"`       // Log message
+}
+
+// Timestamp returns the entry time as a time.Time
+func (e LogEntry) Timestamp() time.Time {
+	return time.UnixMilli(e.Time)
+}
+
+// LogParams contains parameters for querying the system log
+type LogParams struct {
+	Offset int       `json:"offset,omitempty"` // Default: 0
+	Limit  int       `json:"limit,omitempty"`  // [0..200] or LimitMax, Default: 25
+	Start  time.Time `json:"start,omitempty"`  // Only return entries at or after this time
+	End    time.Time `json:"end,omitempty"`    // Only return entries before this time
+	Level  string    `json:"level,omitempty"`  // Only return entries with this level
+}
+
+// validate checks the params before they are sent to the controller
+func (p *LogParams) validate() error {
+	if !p.Start.IsZero() && !p.End.IsZero() && !p.Start.Before(p.End) {
+		return fmt.Errorf("start must be before end")
+	}
+	if p.Level != "" && !knownLogLevels[p.Level] {
+		return fmt.Errorf("invalid log level %q", p.Level)
+	}
+	return nil
+}
+
+// SystemLogResponse represents the response from querying the system log
+type SystemLogResponse struct {
+	PaginatedResponse
+	Data []LogEntry `json:"data"`
+}
+
+// GetSystemLog retrieves a page of the controller's system log for a site,
+// newest first
+func (c *Client) GetSystemLog(ctx context.Context, siteID string, params *LogParams) (*SystemLogResponse, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/system-log", url.PathEscape(siteID))
+
+	if params != nil {
+		if err := params.validate(); err != nil {
+			return nil, err
+		}
+
+		query, err := buildQuery(params, c.maxPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		if len(query) > 0 {
+			urlPath += "?" + query.Encode()
+		}
+	}
+
+	var response SystemLogResponse
+	if err := c.do(ctx, http.MethodGet, urlPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get system log: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,90 @@
+package unifi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_GetSystemLog(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful request", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, SystemLogResponse{
+			PaginatedResponse: PaginatedResponse{Limit: 25, Count: 1, TotalCount: 1},
+			Data: []LogEntry{
+				{ID: "log1", Time: 1700000000000, Level: LogLevelWarn, Subsystem: "wlan", Message: "radar detected on channel 52"},
+			},
+		})
+
+		result, err := client.GetSystemLog(ctx, testSiteID, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(result.Data) != 1 {
+			t.Fatalf("expected 1 entry, got %d", len(result.Data))
+		}
+		entry := result.Data[0]
+		if entry.Level != LogLevelWarn || entry.Subsystem != "wlan" {
+			t.Errorf("unexpected entry: %+v", entry)
+		}
+		if !entry.Timestamp().Equal(time.UnixMilli(1700000000000)) {
+			t.Errorf("expected timestamp %v, got %v", time.UnixMilli(1700000000000), entry.Timestamp())
+		}
+		if got := mock.request.URL.Path; got != "/proxy/network/integration/v1/sites/default/system-log" {
+			t.Errorf("unexpected request path: %s", got)
+		}
+		if got := mock.request.URL.RawQuery; got != "" {
+			t.Errorf("expected no query, got %q", got)
+		}
+	})
+
+	t.Run("query encoding", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(200, SystemLogResponse{})
+
+		start := time.UnixMilli(1700000000000)
+		_, err := client.GetSystemLog(ctx, testSiteID, &LogParams{
+			Limit: 100,
+			Start: start,
+			End:   start.Add(time.Hour),
+			Level: LogLevelError,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := "end=1700003600000&level=error&limit=100&start=1700000000000"
+		if got := mock.request.URL.RawQuery; got != want {
+			t.Errorf("expected query %q, got %q", want, got)
+		}
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		start := time.UnixMilli(1700000000000)
+		tests := []struct {
+			name   string
+			params LogParams
+		}{
+			{name: "end before start", params: LogParams{Start: start, End: start.Add(-time.Minute)}},
+			{name: "empty range", params: LogParams{Start: start, End: start}},
+			{name: "unknown level", params: LogParams{Level: "fatal"}},
+			{name: "limit too large", params: LogParams{Limit: MaxPageLimit + 1}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				client, mock := newTestClient(t, testBaseURL)
+
+				if _, err := client.GetSystemLog(ctx, testSiteID, &tt.params); err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if len(mock.requests) != 0 {
+					t.Errorf("expected no requests, got %d", len(mock.requests))
+				}
+			})
+		}
+	})
+}
@@ -18,11 +18,24 @@ const (
 
 // mockTransport implements http.RoundTripper for testing
 type mockTransport struct {
-	response *http.Response
-	err      error
+	response  *http.Response
+	err       error
+	request   *http.Request    // Last request seen by the transport
+	requests  []*http.Request  // Every request seen by the transport, in order
+	responses []*http.Response // Queued responses, returned in order before response
 }
 
-func (t *mockTransport) RoundTrip(*http.Request) (*http.Response, error) {
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.request = req
+	t.requests = append(t.requests, req)
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	if len(t.responses) > 0 {
+		resp := t.responses[0]
+		t.responses = t.responses[1:]
+		return resp, nil
+	}
 	return t.response, t.err
 }
 
@@ -0,0 +1,49 @@
+package unifi
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Connection settings for the transport NewClient builds when no HTTP client
+// is supplied. Idle connections per host are kept above the fan-out
+// concurrency so batch helpers reuse connections instead of redialing.
+const (
+	transportDialTimeout         = 30 * time.Second
+	transportKeepAlive           = 30 * time.Second
+	transportMaxIdleConns        = 100
+	transportMaxIdleConnsPerHost = 16
+	transportIdleConnTimeout     = 90 * time.Second
+	transportTLSHandshakeTimeout = 10 * time.Second
+)
+
+// newDefaultTransport returns a dedicated transport for one client, so it
+// neither shares connections with nor is affected by changes to
+// http.DefaultTransport
+func newDefaultTransport(insecure bool) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   transportDialTimeout,
+		KeepAlive: transportKeepAlive,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          transportMaxIdleConns,
+		MaxIdleConnsPerHost:   transportMaxIdleConnsPerHost,
+		IdleConnTimeout:       transportIdleConnTimeout,
+		TLSHandshakeTimeout:   transportTLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	if insecure {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return transport
+}
@@ -0,0 +1,74 @@
+package unifi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewClient_DefaultTransport(t *testing.T) {
+	t.Run("dedicated transport", func(t *testing.T) {
+		client, err := NewClient(testBaseURL, WithAPIKey("test-api-key"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if client.httpClient == http.DefaultClient {
+			t.Fatal("expected a dedicated HTTP client, got http.DefaultClient")
+		}
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		if !ok {
+			t.Fatalf("expected *http.Transport, got %T", client.httpClient.Transport)
+		}
+		if transport == http.DefaultTransport {
+			t.Fatal("expected a dedicated transport, got http.DefaultTransport")
+		}
+		if transport.MaxIdleConnsPerHost < defaultConcurrency {
+			t.Errorf("expected at least %d idle connections per host, got %d", defaultConcurrency, transport.MaxIdleConnsPerHost)
+		}
+		if transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify {
+			t.Error("expected certificate verification to be enabled")
+		}
+	})
+
+	t.Run("clients do not share a transport", func(t *testing.T) {
+		first, err := NewClient(testBaseURL, WithAPIKey("test-api-key"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		second, err := NewClient(testBaseURL, WithAPIKey("test-api-key"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if first.httpClient.Transport == second.httpClient.Transport {
+			t.Error("expected each client to have its own transport")
+		}
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		client, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithInsecure(true))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		transport := client.httpClient.Transport.(*http.Transport)
+		if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+			t.Error("expected certificate verification to be disabled")
+		}
+		if defaultTLS := http.DefaultTransport.(*http.Transport).TLSClientConfig; defaultTLS != nil && defaultTLS.InsecureSkipVerify {
+			t.Error("expected http.DefaultTransport to be left untouched")
+		}
+	})
+
+	t.Run("custom client is kept", func(t *testing.T) {
+		custom := &http.Client{}
+		client, err := NewClient(testBaseURL, WithAPIKey("test-api-key"), WithHTTPClient(custom))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if client.httpClient != custom {
+			t.Error("expected the supplied HTTP client to be used")
+		}
+	})
+}
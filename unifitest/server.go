@@ -0,0 +1,137 @@
+package unifitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+// apiPrefix mirrors the path under which the controller serves the integration API
+const apiPrefix = "/proxy/network/integration"
+
+// Server is a fake controller serving the read-only list endpoints for the
+// sites loaded into it, with offset/limit pagination. It is started by
+// NewServer and must be closed by the caller.
+type Server struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	sites map[string]*unifi.SiteSnapshot
+	order []string // Site IDs in load order
+}
+
+// NewServer starts a fake controller serving the given snapshots
+func NewServer(snapshots ...*unifi.SiteSnapshot) *Server {
+	s := &Server{sites: make(map[string]*unifi.SiteSnapshot)}
+	for _, snapshot := range snapshots {
+		s.LoadSnapshot(snapshot)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// LoadSnapshot adds a site to the server, replacing any site with the same ID
+func (s *Server) LoadSnapshot(snapshot *unifi.SiteSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sites[snapshot.SiteID]; !ok {
+		s.order = append(s.order, snapshot.SiteID)
+	}
+	s.sites[snapshot.SiteID] = snapshot
+}
+
+// NewClient returns a unifi client pointed at the server
+func (s *Server) NewClient(options ...unifi.ClientOption) (*unifi.Client, error) {
+	options = append([]unifi.ClientOption{unifi.WithAPIKey("unifitest")}, options...)
+	return unifi.NewClient(s.URL, options...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, apiPrefix)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "v1" || segments[1] != "sites" {
+		writeError(w, r, http.StatusNotFound, "not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(segments) == 2 {
+		sites := make([]unifi.Site, 0, len(s.order))
+		for _, id := range s.order {
+			sites = append(sites, unifi.Site{ID: id, Name: id})
+		}
+		writePage(w, r, sites)
+		return
+	}
+
+	site, ok := s.sites[segments[2]]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, fmt.Sprintf("site not found: %s", segments[2]))
+		return
+	}
+
+	switch strings.Join(segments[3:], "/") {
+	case "devices":
+		writePage(w, r, site.Devices)
+	case "clients":
+		writePage(w, r, site.Clients)
+	case "hotspot/vouchers":
+		writePage(w, r, site.Vouchers)
+	default:
+		writeError(w, r, http.StatusNotFound, "not found")
+	}
+}
+
+// writePage writes the offset/limit window of items as a paginated response
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	query := r.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 25
+	}
+
+	offset = min(max(offset, 0), len(items))
+	end := min(offset+limit, len(items))
+	page := items[offset:end]
+	if page == nil {
+		page = []T{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"offset":     offset,
+		"limit":      limit,
+		"count":      len(page),
+		"totalCount": len(items),
+		"data":       page,
+	})
+}
+
+// writeError writes an error in the controller's format
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeJSON(w, status, unifi.Error{
+		Status:      status,
+		StatusName:  http.StatusText(status),
+		Message:     message,
+		RequestPath: r.URL.Path,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,118 @@
+package unifitest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/klauern/unifi-network-go"
+)
+
+func testSnapshot() *unifi.SiteSnapshot {
+	snapshot := &unifi.SiteSnapshot{
+		SiteID: "default",
+		Clients: []unifi.NetworkClient{
+			{ID: "client1", Name: "Laptop", MACAddress: "00:11:22:33:44:55", IPAddress: "192.168.1.10", Type: "WIRELESS"},
+			{ID: "client2", Name: "NAS", MACAddress: "00:11:22:33:44:66", IPAddress: "192.168.1.20", Type: "WIRED", IsWired: true},
+		},
+		Vouchers: []unifi.HotspotVoucher{
+			{ID: "voucher1", Code: "1234567890", Name: "Lobby", TimeLimitMinutes: 60},
+		},
+	}
+
+	// Enough devices to span several pages of the controller's maximum size
+	for i := 0; i < 2*unifi.MaxPageLimit+5; i++ {
+		snapshot.Devices = append(snapshot.Devices, unifi.Device{
+			ID:   fmt.Sprintf("device%d", i),
+			Name: fmt.Sprintf("AP %d", i),
+			MAC:  fmt.Sprintf("00:00:00:00:%02x:%02x", i/256, i%256),
+			Type: unifi.DeviceTypeAccessPoint,
+		})
+	}
+
+	return snapshot
+}
+
+func TestServer_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	want := testSnapshot()
+
+	server := NewServer(want)
+	defer server.Close()
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var first bytes.Buffer
+	if err := client.SnapshotSite(ctx, "default", &first); err != nil {
+		t.Fatalf("failed to snapshot site: %v", err)
+	}
+
+	got, err := unifi.LoadSiteSnapshot(bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshot did not round-trip:\ngot  %+v\nwant %+v", got, want)
+	}
+
+	// Replaying the loaded snapshot must produce an identical document
+	replay := NewServer(got)
+	defer replay.Close()
+
+	replayClient, err := replay.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var second bytes.Buffer
+	if err := replayClient.SnapshotSite(ctx, "default", &second); err != nil {
+		t.Fatalf("failed to snapshot replayed site: %v", err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Errorf("replayed snapshot differs:\n%s\nvs\n%s", first.String(), second.String())
+	}
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	server := NewServer(testSnapshot())
+	defer server.Close()
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	t.Run("lists loaded sites", func(t *testing.T) {
+		sites, err := client.ListAllSites(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(sites) != 1 || sites[0].ID != "default" {
+			t.Errorf("expected site default, got %+v", sites)
+		}
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		resp, err := client.ListDevices(ctx, "default", &unifi.ListDevicesParams{Offset: 400, Limit: 10})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Count != 5 || resp.TotalCount != 2*unifi.MaxPageLimit+5 || resp.Data[0].ID != "device400" {
+			t.Errorf("unexpected page: count=%d total=%d first=%s", resp.Count, resp.TotalCount, resp.Data[0].ID)
+		}
+	})
+
+	t.Run("unknown site", func(t *testing.T) {
+		_, err := client.ListAllDevices(ctx, "missing")
+		if !unifi.IsNotFound(err) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
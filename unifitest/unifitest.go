@@ -0,0 +1,36 @@
+// Package unifitest provides helpers for testing code built on the unifi
+// client.
+package unifitest
+
+import (
+	"testing"
+	"time"
+)
+
+// Eventually polls cond every interval until it returns true, failing the
+// test with Fatalf if that does not happen within timeout. cond is checked
+// once immediately, so a condition that already holds never waits.
+func Eventually(t testing.TB, timeout, interval time.Duration, cond func() bool) {
+	t.Helper()
+
+	if cond() {
+		return
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-deadline.C:
+			t.Fatalf("condition not met within %s", timeout)
+			return
+		case <-ticker.C:
+			if cond() {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package unifitest
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingT captures Fatalf calls so failures can be asserted without
+// failing the enclosing test
+type recordingT struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Fatalf(format string, args ...interface{}) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
+
+func TestEventually(t *testing.T) {
+	t.Run("condition becomes true", func(t *testing.T) {
+		var calls atomic.Int32
+		rt := &recordingT{TB: t}
+
+		Eventually(rt, time.Second, time.Millisecond, func() bool {
+			return calls.Add(1) >= 3
+		})
+
+		if rt.failed {
+			t.Errorf("unexpected failure: %s", rt.message)
+		}
+		if got := calls.Load(); got != 3 {
+			t.Errorf("expected 3 checks, got %d", got)
+		}
+	})
+
+	t.Run("already true does not wait", func(t *testing.T) {
+		rt := &recordingT{TB: t}
+		start := time.Now()
+
+		Eventually(rt, time.Second, time.Hour, func() bool { return true })
+
+		if rt.failed {
+			t.Errorf("unexpected failure: %s", rt.message)
+		}
+		if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+			t.Errorf("expected immediate return, took %s", elapsed)
+		}
+	})
+
+	t.Run("timeout fails the test", func(t *testing.T) {
+		rt := &recordingT{TB: t}
+
+		Eventually(rt, 20*time.Millisecond, time.Millisecond, func() bool { return false })
+
+		if !rt.failed {
+			t.Fatal("expected failure after timeout")
+		}
+		if want := "condition not met within 20ms"; rt.message != want {
+			t.Errorf("expected message %q, got %q", want, rt.message)
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// UserGroup is a bandwidth profile that clients can be assigned to
+type UserGroup struct {
+	ID           string `json:"_id"`               // Unique identifier
+	Name         string `json:"name"`              // Group name
+	DownRateKbps int    `json:"qos_rate_max_down"` // Download limit in Kbps, -1 for unlimited
+	UpRateKbps   int    `json:"qos_rate_max_up"`   // Upload limit in Kbps, -1 for unlimited
+	BuiltIn      bool   `json:"attr_no_delete"`    // Whether the group is built in and cannot be deleted (e.g., Default)
+}
+
+// ListUserGroups retrieves the user groups (bandwidth profiles) for a site
+func (c *Client) ListUserGroups(ctx context.Context, siteID string) ([]UserGroup, error) {
+	if err := validateSiteID(siteID); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data []UserGroup `json:"data"`
+	}
+
+	urlPath := fmt.Sprintf("/v1/sites/%s/user-groups", url.PathEscape(siteID))
+	if err := c.do(ctx, http.MethodGet, urlPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list user groups: %w", err)
+	}
+
+	if response.Data == nil {
+		return []UserGroup{}, nil
+	}
+
+	return response.Data, nil
+}
@@ -0,0 +1,57 @@
+package unifi
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_ListUserGroups(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful response", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":[
+			{"_id":"g1","name":"Default","qos_rate_max_down":-1,"qos_rate_max_up":-1,"attr_no_delete":true},
+			{"_id":"g2","name":"Guests 10M","qos_rate_max_down":10000,"qos_rate_max_up":2000}
+		]}`)
+
+		groups, err := client.ListUserGroups(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := mock.request.URL.Path; !strings.HasSuffix(got, "/v1/sites/default/user-groups") {
+			t.Errorf("unexpected request path: %s", got)
+		}
+		want := []UserGroup{
+			{ID: "g1", Name: "Default", DownRateKbps: -1, UpRateKbps: -1, BuiltIn: true},
+			{ID: "g2", Name: "Guests 10M", DownRateKbps: 10000, UpRateKbps: 2000},
+		}
+		if !reflect.DeepEqual(groups, want) {
+			t.Errorf("expected groups %+v, got %+v", want, groups)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockRawResponse(200, `{"data":null}`)
+
+		groups, err := client.ListUserGroups(ctx, testSiteID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if groups == nil || len(groups) != 0 {
+			t.Errorf("expected empty non-nil slice, got %#v", groups)
+		}
+	})
+
+	t.Run("missing site ID", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+
+		if _, err := client.ListUserGroups(ctx, ""); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package unifi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// validateStruct checks the fields of a request struct against their
+// `validate` tags and returns the first violation in field order. Fields are
+// reported by their JSON name so messages match the API documentation.
+//
+// Supported rules:
+//
+//	required   the field must not be the zero value
+//	omitempty  skip the remaining rules when the field is the zero value
+//	min=N      integer fields must be >= N
+//	max=N      integer fields must be <= N
+func validateStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fmt.Errorf("request cannot be nil")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validateStruct: unsupported type %s", rv.Type())
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok || !field.IsExported() {
+			continue
+		}
+		if err := validateField(fieldName(field), rv.Field(i), tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validationRules holds the parsed contents of a `validate` tag.
+type validationRules struct {
+	required  bool
+	omitempty bool
+	min, max  *int64
+}
+
+func parseValidationRules(tag string) (validationRules, error) {
+	var rules validationRules
+	for _, part := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "":
+		case "required":
+			rules.required = true
+		case "omitempty":
+			rules.omitempty = true
+		case "min", "max":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return rules, fmt.Errorf("invalid %s value %q: %w", key, value, err)
+			}
+			if key == "min" {
+				rules.min = &n
+			} else {
+				rules.max = &n
+			}
+		default:
+			return rules, fmt.Errorf("unknown validation rule %q", key)
+		}
+	}
+	return rules, nil
+}
+
+func validateField(name string, fv reflect.Value, tag string) error {
+	rules, err := parseValidationRules(tag)
+	if err != nil {
+		return fmt.Errorf("invalid validate tag on %s: %w", name, err)
+	}
+
+	if fv.IsZero() {
+		if rules.required {
+			return fmt.Errorf("%s is required", name)
+		}
+		if rules.omitempty {
+			return nil
+		}
+	}
+
+	if rules.min == nil && rules.max == nil {
+		return nil
+	}
+
+	var n int64
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = fv.Int()
+	default:
+		return fmt.Errorf("invalid validate tag on %s: min/max require an integer field", name)
+	}
+
+	switch {
+	case rules.min != nil && rules.max != nil:
+		if n < *rules.min || n > *rules.max {
+			return fmt.Errorf("%s must be between %d and %d", name, *rules.min, *rules.max)
+		}
+	case rules.min != nil:
+		if n < *rules.min {
+			return fmt.Errorf("%s must be greater than %d", name, *rules.min-1)
+		}
+	case rules.max != nil:
+		if n > *rules.max {
+			return fmt.Errorf("%s must be less than %d", name, *rules.max+1)
+		}
+	}
+	return nil
+}
+
+// fieldName returns the JSON name of a struct field, falling back to the Go
+// field name when there is no json tag.
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,183 @@
+package unifi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateStruct(t *testing.T) {
+	type sample struct {
+		Name    string `json:"name" validate:"required"`
+		Size    int    `json:"size" validate:"min=1,max=10"`
+		Limit   int    `json:"limit,omitempty" validate:"omitempty,min=1"`
+		Ceiling int    `json:"ceiling,omitempty" validate:"omitempty,max=5"`
+		Plain   int    `validate:"min=0,max=3"`
+		Ignored int    `json:"ignored"`
+	}
+
+	valid := func() sample { return sample{Name: "x", Size: 1} }
+
+	tests := []struct {
+		name    string
+		mutate  func(*sample)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*sample) {}},
+		{name: "required", mutate: func(s *sample) { s.Name = "" }, wantErr: "name is required"},
+		{name: "below range", mutate: func(s *sample) { s.Size = 0 }, wantErr: "size must be between 1 and 10"},
+		{name: "above range", mutate: func(s *sample) { s.Size = 11 }, wantErr: "size must be between 1 and 10"},
+		{name: "omitempty zero", mutate: func(s *sample) { s.Limit = 0 }},
+		{name: "min only", mutate: func(s *sample) { s.Limit = -1 }, wantErr: "limit must be greater than 0"},
+		{name: "max only", mutate: func(s *sample) { s.Ceiling = 6 }, wantErr: "ceiling must be less than 6"},
+		{name: "go name fallback", mutate: func(s *sample) { s.Plain = 4 }, wantErr: "Plain must be between 0 and 3"},
+		{name: "untagged ignored", mutate: func(s *sample) { s.Ignored = -100 }},
+		{name: "first failure wins", mutate: func(s *sample) { s.Name = ""; s.Size = 0 }, wantErr: "name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid()
+			tt.mutate(&s)
+			err := validateStruct(&s)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("nil pointer", func(t *testing.T) {
+		var s *sample
+		if err := validateStruct(s); err == nil || err.Error() != "request cannot be nil" {
+			t.Fatalf("error = %v, want nil request error", err)
+		}
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		type bad struct {
+			N int `json:"n" validate:"min=abc"`
+		}
+		err := validateStruct(bad{})
+		if err == nil || !strings.Contains(err.Error(), "invalid validate tag on n") {
+			t.Fatalf("error = %v, want invalid tag error", err)
+		}
+	})
+
+	t.Run("unknown rule", func(t *testing.T) {
+		type bad struct {
+			N int `json:"n" validate:"email"`
+		}
+		err := validateStruct(bad{})
+		if err == nil || !strings.Contains(err.Error(), `unknown validation rule "email"`) {
+			t.Fatalf("error = %v, want unknown rule error", err)
+		}
+	})
+
+	t.Run("range on non-integer", func(t *testing.T) {
+		type bad struct {
+			S string `json:"s" validate:"min=1"`
+		}
+		err := validateStruct(bad{S: "x"})
+		if err == nil || !strings.Contains(err.Error(), "require an integer field") {
+			t.Fatalf("error = %v, want integer field error", err)
+		}
+	})
+}
+
+// legacyVoucherValidation mirrors the hand-written checks that
+// GenerateHotspotVouchers used before the validate tags were introduced.
+func legacyVoucherValidation(r *GenerateHotspotVouchersRequest) error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if r.Count < 1 || r.Count > maxVoucherBatch {
+		return fmt.Errorf("count must be between 1 and %d", maxVoucherBatch)
+	}
+	if r.TimeLimitMinutes < 1 || r.TimeLimitMinutes > 1000000 {
+		return fmt.Errorf("timeLimitMinutes must be between 1 and 1000000")
+	}
+	if r.AuthorizeGuestLimit < 0 {
+		return fmt.Errorf("authorizedGuestLimit must be greater than 0")
+	}
+	if r.DataUsageLimitMB != 0 && (r.DataUsageLimitMB < 1 || r.DataUsageLimitMB > 1046576) {
+		return fmt.Errorf("dataUsageLimitMBytes must be between 1 and 1046576")
+	}
+	if r.RxRateLimitKbps != 0 && (r.RxRateLimitKbps < 2 || r.RxRateLimitKbps > 100000) {
+		return fmt.Errorf("rxRateLimitKbps must be between 2 and 100000")
+	}
+	if r.TxRateLimitKbps != 0 && (r.TxRateLimitKbps < 2 || r.TxRateLimitKbps > 100000) {
+		return fmt.Errorf("txRateLimitKbps must be between 2 and 100000")
+	}
+	return nil
+}
+
+func TestValidateStructMatchesLegacyVoucherErrors(t *testing.T) {
+	base := GenerateHotspotVouchersRequest{Count: 1, Name: "Test", TimeLimitMinutes: 1}
+
+	// Boundary values for every validated field; each case changes one field.
+	cases := map[string][]int{
+		"Count":               {-1, 0, 1, 5000, maxVoucherBatch, maxVoucherBatch + 1},
+		"AuthorizeGuestLimit": {-1, 0, 1, 100},
+		"TimeLimitMinutes":    {-1, 0, 1, 1000000, 1000001},
+		"DataUsageLimitMB":    {-1, 0, 1, 1046576, 1046577},
+		"RxRateLimitKbps":     {-1, 0, 1, 2, 100000, 100001},
+		"TxRateLimitKbps":     {-1, 0, 1, 2, 100000, 100001},
+	}
+
+	for field, values := range cases {
+		for _, v := range values {
+			t.Run(fmt.Sprintf("%s=%d", field, v), func(t *testing.T) {
+				req := base
+				reflect.ValueOf(&req).Elem().FieldByName(field).SetInt(int64(v))
+				assertSameError(t, validateStruct(&req), legacyVoucherValidation(&req))
+			})
+		}
+	}
+
+	t.Run("Name empty", func(t *testing.T) {
+		req := base
+		req.Name = ""
+		assertSameError(t, validateStruct(&req), legacyVoucherValidation(&req))
+	})
+
+	// With several invalid fields the error is for the first in declaration
+	// order, which may differ from the one the old checks reported
+	t.Run("first invalid field wins", func(t *testing.T) {
+		req := GenerateHotspotVouchersRequest{AuthorizeGuestLimit: -1, RxRateLimitKbps: 1}
+		err := validateStruct(&req)
+		if err == nil || err.Error() != "count must be between 1 and 10000" {
+			t.Fatalf(`error = %v, want "count must be between 1 and 10000"`, err)
+		}
+	})
+}
+
+func TestVoucherCountTagMatchesMaxVoucherBatch(t *testing.T) {
+	field, ok := reflect.TypeOf(GenerateHotspotVouchersRequest{}).FieldByName("Count")
+	if !ok {
+		t.Fatal("GenerateHotspotVouchersRequest has no Count field")
+	}
+	rules, err := parseValidationRules(field.Tag.Get("validate"))
+	if err != nil {
+		t.Fatalf("invalid validate tag: %v", err)
+	}
+	if rules.max == nil || *rules.max != maxVoucherBatch {
+		t.Errorf("Count validate max = %v, want maxVoucherBatch (%d)", rules.max, maxVoucherBatch)
+	}
+}
+
+func assertSameError(t *testing.T, got, want error) {
+	t.Helper()
+	if (got == nil) != (want == nil) {
+		t.Fatalf("error = %v, want %v", got, want)
+	}
+	if got != nil && got.Error() != want.Error() {
+		t.Fatalf("error = %q, want %q", got.Error(), want.Error())
+	}
+}
@@ -0,0 +1,87 @@
+package unifi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SemVer parses ApplicationVersion into its major, minor and patch numbers.
+// A pre-release suffix such as "-beta" is accepted and ignored.
+func (a ApplicationInfo) SemVer() (major, minor, patch int, err error) {
+	major, minor, patch, _, err = parseVersion(a.ApplicationVersion)
+	return major, minor, patch, err
+}
+
+// CompareVersion compares ApplicationVersion with other, returning -1, 0 or
+// +1 when the controller's version is lower than, equal to or higher than
+// other. A pre-release sorts before its release ("9.1.0-beta" < "9.1.0").
+// A version that cannot be parsed sorts before any valid version.
+func (a ApplicationInfo) CompareVersion(other string) int {
+	return compareVersions(a.ApplicationVersion, other)
+}
+
+// parseVersion splits a version such as "9.1.0" or "9.1.0-beta.2" into its
+// numeric parts and pre-release suffix. Build metadata after '+' is ignored.
+func parseVersion(version string) (major, minor, patch int, prerelease string, err error) {
+	v, _, _ := strings.Cut(strings.TrimSpace(version), "+")
+	core, prerelease, _ := strings.Cut(v, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, "", fmt.Errorf("invalid version %q: expected major.minor.patch", version)
+	}
+
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, 0, 0, "", fmt.Errorf("invalid version %q: %q is not a number", version, part)
+		}
+		nums[i] = n
+	}
+
+	return nums[0], nums[1], nums[2], prerelease, nil
+}
+
+// compareVersions orders two version strings as described on CompareVersion
+func compareVersions(a, b string) int {
+	aMajor, aMinor, aPatch, aPre, aErr := parseVersion(a)
+	bMajor, bMinor, bPatch, bPre, bErr := parseVersion(b)
+	switch {
+	case aErr != nil && bErr != nil:
+		return 0
+	case aErr != nil:
+		return -1
+	case bErr != nil:
+		return 1
+	}
+
+	for _, pair := range [][2]int{{aMajor, bMajor}, {aMinor, bMinor}, {aPatch, bPatch}} {
+		if c := compareInts(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	default:
+		return strings.Compare(aPre, bPre)
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
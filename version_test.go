@@ -0,0 +1,68 @@
+package unifi
+
+import "testing"
+
+func TestApplicationInfo_SemVer(t *testing.T) {
+	tests := []struct {
+		version string
+		want    [3]int
+		wantErr bool
+	}{
+		{version: "9.1.0", want: [3]int{9, 1, 0}},
+		{version: "8.6.9", want: [3]int{8, 6, 9}},
+		{version: "9.1.0-beta", want: [3]int{9, 1, 0}},
+		{version: "9.2.3-rc.1+build.42", want: [3]int{9, 2, 3}},
+		{version: "", wantErr: true},
+		{version: "9.1", wantErr: true},
+		{version: "9.1.0.1", wantErr: true},
+		{version: "9.x.0", wantErr: true},
+		{version: "v9.1.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			major, minor, patch, err := ApplicationInfo{ApplicationVersion: tt.version}.SemVer()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %d.%d.%d", major, minor, patch)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := [3]int{major, minor, patch}; got != tt.want {
+				t.Errorf("SemVer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplicationInfo_CompareVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		other   string
+		want    int
+	}{
+		{version: "9.1.0", other: "9.1.0", want: 0},
+		{version: "9.1.0", other: "9.0.114", want: 1},
+		{version: "8.6.9", other: "9.0.0", want: -1},
+		{version: "9.1.10", other: "9.1.9", want: 1},
+		{version: "9.1.0-beta", other: "9.1.0", want: -1},
+		{version: "9.1.0", other: "9.1.0-beta", want: 1},
+		{version: "9.1.0-alpha", other: "9.1.0-beta", want: -1},
+		{version: "9.1.0+build.1", other: "9.1.0", want: 0},
+		{version: "garbage", other: "9.1.0", want: -1},
+		{version: "9.1.0", other: "garbage", want: 1},
+		{version: "garbage", other: "junk", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" vs "+tt.other, func(t *testing.T) {
+			info := ApplicationInfo{ApplicationVersion: tt.version}
+			if got := info.CompareVersion(tt.other); got != tt.want {
+				t.Errorf("CompareVersion(%q) = %d, want %d", tt.other, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ClientEventType describes a change in a site's connected clients
+type ClientEventType string
+
+// Client presence events reported by WatchNetworkClients
+const (
+	ClientConnected    ClientEventType = "connected"
+	ClientDisconnected ClientEventType = "disconnected"
+)
+
+// ClientEvent reports that a client connected to or disconnected from a site
+type ClientEvent struct {
+	Type   ClientEventType
+	Client NetworkClient // For a disconnect, the client as last seen
+	Time   time.Time     // When the change was observed
+}
+
+// WatchNetworkClients polls a site's clients every interval and calls fn for
+// each client that connects or disconnects between polls. Clients present on
+// the first poll are the baseline and are not reported. It blocks until ctx
+// is done, returning ctx.Err(), or until listing clients fails.
+func (c *Client) WatchNetworkClients(ctx context.Context, siteID string, interval time.Duration, fn func(ClientEvent)) error {
+	if err := validateSiteID(siteID); err != nil {
+		return err
+	}
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	var previous []NetworkClient
+	var known map[string]bool
+	return c.poll(ctx, interval, func() (bool, error) {
+		clients, err := c.ListAllNetworkClients(ctx, siteID)
+		if err != nil {
+			return false, fmt.Errorf("failed to watch network clients: %w", err)
+		}
+		now := c.clock.Now()
+
+		current := make(map[string]bool, len(clients))
+		for _, client := range clients {
+			current[clientKey(client)] = true
+		}
+
+		if known != nil {
+			for _, client := range clients {
+				if !known[clientKey(client)] {
+					fn(ClientEvent{Type: ClientConnected, Client: client, Time: now})
+				}
+			}
+			for _, client := range previous {
+				if !current[clientKey(client)] {
+					fn(ClientEvent{Type: ClientDisconnected, Client: client, Time: now})
+				}
+			}
+		}
+
+		previous, known = clients, current
+		return false, nil
+	})
+}
+
+// clientKey identifies a client across polls by MAC address, falling back to its ID
+func clientKey(client NetworkClient) string {
+	if mac, err := NormalizeMAC(client.MACAddress); err == nil {
+		return mac
+	}
+	return client.ID
+}
@@ -0,0 +1,96 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func clientsResponse(clients ...NetworkClient) *http.Response {
+	return mockResponse(200, ListNetworkClientsResponse{
+		Count:      len(clients),
+		TotalCount: len(clients),
+		Data:       clients,
+	})
+}
+
+func TestClient_WatchNetworkClients(t *testing.T) {
+	laptop := NetworkClient{ID: "1", Name: "Laptop", MACAddress: "00:11:22:33:44:01"}
+	phone := NetworkClient{ID: "2", Name: "Phone", MACAddress: "00:11:22:33:44:02"}
+	tablet := NetworkClient{ID: "3", Name: "Tablet", MACAddress: "00:11:22:33:44:03"}
+
+	t.Run("reports connects and disconnects", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		clock := newFakeClock()
+		client.clock = clock
+		mock.responses = []*http.Response{
+			clientsResponse(laptop, phone),
+			// Same client in another notation is not a reconnect
+			clientsResponse(NetworkClient{ID: "2", Name: "Phone", MACAddress: "00-11-22-33-44-02"}, tablet),
+			clientsResponse(tablet),
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var events []ClientEvent
+		done := make(chan error, 1)
+		go func() {
+			done <- client.WatchNetworkClients(ctx, testSiteID, time.Minute, func(ev ClientEvent) {
+				events = append(events, ev)
+			})
+		}()
+
+		for i := 0; i < 2; i++ {
+			clock.waitForWaiters(t, 1)
+			clock.Advance(time.Minute)
+		}
+		clock.waitForWaiters(t, 1)
+		cancel()
+
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+
+		want := []struct {
+			typ ClientEventType
+			id  string
+		}{
+			{ClientConnected, "3"},
+			{ClientDisconnected, "1"},
+			{ClientDisconnected, "2"},
+		}
+		if len(events) != len(want) {
+			t.Fatalf("expected %d events, got %d: %+v", len(want), len(events), events)
+		}
+		for i, w := range want {
+			if events[i].Type != w.typ || events[i].Client.ID != w.id {
+				t.Errorf("event %d: expected %s %s, got %s %s", i, w.typ, w.id, events[i].Type, events[i].Client.ID)
+			}
+		}
+		if wantTime := newFakeClock().Now().Add(2 * time.Minute); !events[2].Time.Equal(wantTime) {
+			t.Errorf("expected event time %v, got %v", wantTime, events[2].Time)
+		}
+	})
+
+	t.Run("list error stops the watch", func(t *testing.T) {
+		client, mock := newTestClient(t, testBaseURL)
+		mock.response = mockResponse(500, map[string]string{"message": "boom"})
+
+		err := client.WatchNetworkClients(context.Background(), testSiteID, time.Minute, func(ClientEvent) {
+			t.Error("unexpected event")
+		})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		client, _ := newTestClient(t, testBaseURL)
+		if err := client.WatchNetworkClients(context.Background(), testSiteID, 0, func(ClientEvent) {}); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}